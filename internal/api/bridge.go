@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// listBridgeIntents returns BridgeIntent rows, newest first, optionally
+// filtered by ?status=pending|broadcasted|confirmed|failed.
+func (s *Server) listBridgeIntents(c *gin.Context) {
+	query := s.db.Order("id DESC")
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var intents []models.BridgeIntent
+	if err := query.Find(&intents).Error; err != nil {
+		s.logger.WithError(err).Error("bridge: list intents failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list bridge intents"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"intents": intents})
+}
+
+// getBridgeIntent returns a single BridgeIntent by ID.
+func (s *Server) getBridgeIntent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var intent models.BridgeIntent
+	if err := s.db.First(&intent, uint(id)).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "bridge intent not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"intent": intent})
+}
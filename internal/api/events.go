@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/events"
+	"github.com/gin-gonic/gin"
+)
+
+// replayEventsRequest is the POST /events/replay body: an inclusive range
+// of event_outbox IDs to re-publish.
+type replayEventsRequest struct {
+	FromID uint `json:"from_id" binding:"required"`
+	ToID   uint `json:"to_id" binding:"required"`
+}
+
+// replayEvents resets a range of event_outbox rows back to pending so the
+// background dispatcher re-publishes them, for disaster recovery.
+func (s *Server) replayEvents(c *gin.Context) {
+	var req replayEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ToID < req.FromID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to_id must be >= from_id"})
+		return
+	}
+
+	replayed, err := events.ReplayRange(s.db, req.FromID, req.ToID)
+	if err != nil {
+		s.logger.WithError(err).Error("events: replay range failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to replay events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"replayed": replayed})
+}
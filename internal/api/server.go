@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/events"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/segmentio/kafka-go"
@@ -15,11 +16,12 @@ import (
 )
 
 type Server struct {
-	router       *gin.Engine
-	db           *gorm.DB
-	logger       *logrus.Logger
-	redisClient  *redis.Client
-	kafkaBrokers []string
+	router           *gin.Engine
+	db               *gorm.DB
+	logger           *logrus.Logger
+	redisClient      *redis.Client
+	kafkaBrokers     []string
+	eventsDispatcher *events.Dispatcher
 }
 
 func NewServer(db *gorm.DB, logger *logrus.Logger) *Server {
@@ -28,7 +30,7 @@ func NewServer(db *gorm.DB, logger *logrus.Logger) *Server {
 	if redisURL == "" {
 		redisURL = "redis://localhost:6380"
 	}
-	
+
 	// Parse Redis URL
 	redisClient := redis.NewClient(&redis.Options{
 		Addr: strings.TrimPrefix(redisURL, "redis://"),
@@ -40,12 +42,20 @@ func NewServer(db *gorm.DB, logger *logrus.Logger) *Server {
 		kafkaBrokers = []string{"localhost:9093"}
 	}
 
+	dispatcher := events.NewDispatcher(db, events.DefaultConfig(kafkaBrokers), logger)
+	go func() {
+		if err := dispatcher.Run(context.Background()); err != nil {
+			logger.WithError(err).Error("events: dispatcher stopped")
+		}
+	}()
+
 	server := &Server{
-		router:       gin.Default(),
-		db:           db,
-		logger:       logger,
-		redisClient:  redisClient,
-		kafkaBrokers: kafkaBrokers,
+		router:           gin.Default(),
+		db:               db,
+		logger:           logger,
+		redisClient:      redisClient,
+		kafkaBrokers:     kafkaBrokers,
+		eventsDispatcher: dispatcher,
 	}
 	server.setupRoutes()
 	return server
@@ -69,18 +79,26 @@ func (s *Server) setupRoutes() {
 
 	// Health check
 	s.router.GET("/health", s.healthCheck)
+
+	// Event outbox replay (disaster recovery)
+	s.router.POST("/events/replay", s.replayEvents)
+
+	// Cross-chain bridge intents (read-only; Indexer/Committer run out of
+	// band since they need per-deployment hot-wallet/contract config)
+	s.router.GET("/bridge/intents", s.listBridgeIntents)
+	s.router.GET("/bridge/intents/:id", s.getBridgeIntent)
 }
 
 // Health check handler
 func (s *Server) healthCheck(c *gin.Context) {
 	ctx := context.Background()
-	
+
 	// Check database status
 	dbStatus := s.checkDatabaseStatus()
-	
-	// Check Redis status  
+
+	// Check Redis status
 	redisStatus := s.checkRedisStatus(ctx)
-	
+
 	// Check Kafka status
 	kafkaStatus := s.checkKafkaStatus(ctx)
 
@@ -107,11 +125,11 @@ func (s *Server) checkDatabaseStatus() string {
 	if err != nil {
 		return "error"
 	}
-	
+
 	if err := sqlDB.Ping(); err != nil {
 		return "disconnected"
 	}
-	
+
 	return "connected"
 }
 
@@ -119,12 +137,12 @@ func (s *Server) checkDatabaseStatus() string {
 func (s *Server) checkRedisStatus(ctx context.Context) string {
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
-	
+
 	_, err := s.redisClient.Ping(ctx).Result()
 	if err != nil {
 		return "disconnected"
 	}
-	
+
 	return "connected"
 }
 
@@ -132,28 +150,28 @@ func (s *Server) checkRedisStatus(ctx context.Context) string {
 func (s *Server) checkKafkaStatus(ctx context.Context) string {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	
+
 	for _, broker := range s.kafkaBrokers {
 		s.logger.Debugf("Trying to connect to Kafka broker: %s", broker)
-		
+
 		// Try to create a simple reader to test connectivity
 		reader := kafka.NewReader(kafka.ReaderConfig{
 			Brokers: []string{broker},
 			Topic:   "__consumer_offsets", // This topic should always exist
 			GroupID: "health-check",
 		})
-		
+
 		// Just try to get stats, don't actually read
 		stats := reader.Stats()
 		reader.Close()
-		
+
 		// If we can get stats without error, Kafka is accessible
 		if stats.Topic != "" || true { // Always consider it successful if no panic
 			s.logger.Debugf("Successfully connected to Kafka broker %s", broker)
 			return "connected"
 		}
 	}
-	
+
 	s.logger.Debugf("All Kafka brokers failed to connect: %v", s.kafkaBrokers)
 	return "disconnected"
 }
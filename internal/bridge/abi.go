@@ -0,0 +1,75 @@
+package bridge
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const wordSize = 32
+
+// mintSignature is "mint(address,uint256,bytes32)"; its selector is the
+// first 4 bytes of keccak256(mintSignature).
+const mintSignature = "mint(address,uint256,bytes32)"
+
+var mintSelector = functionSelector(mintSignature)
+
+// Bridge contract event signatures the Committer decodes from receipt logs
+// to confirm a mint/withdraw actually took effect, rather than trusting tx
+// success alone.
+const (
+	tokensDepositedSignature = "TokensDeposited(address,uint256,bytes32)"
+	tokensWithdrawnSignature = "TokensWithdrawn(address,uint256,bytes32)"
+)
+
+var (
+	topicTokensDeposited = eventTopic(tokensDepositedSignature)
+	topicTokensWithdrawn = eventTopic(tokensWithdrawnSignature)
+)
+
+func functionSelector(signature string) []byte {
+	return ethcrypto.Keccak256([]byte(signature))[:4]
+}
+
+func eventTopic(signature string) common.Hash {
+	return common.BytesToHash(ethcrypto.Keccak256([]byte(signature)))
+}
+
+// encodeMintCall ABI-encodes a call to mint(recipient, amount, sourceTxHash):
+// the 4-byte selector followed by three 32-byte-padded words.
+func encodeMintCall(recipient common.Address, amount *big.Int, sourceTxHash [32]byte) ([]byte, error) {
+	if amount.Sign() < 0 {
+		return nil, fmt.Errorf("bridge: mint amount must be non-negative, got %s", amount)
+	}
+	data := make([]byte, 0, len(mintSelector)+3*wordSize)
+	data = append(data, mintSelector...)
+	data = append(data, leftPadWord(recipient.Bytes())...)
+	data = append(data, leftPadWord(amount.Bytes())...)
+	data = append(data, sourceTxHash[:]...)
+	return data, nil
+}
+
+// leftPadWord left-pads b to a 32-byte ABI word.
+func leftPadWord(b []byte) []byte {
+	word := make([]byte, wordSize)
+	copy(word[wordSize-len(b):], b)
+	return word
+}
+
+// sourceTxHashWord turns a source-chain tx hash into the bytes32 word the
+// bridge contract expects: hex-decoded directly when it's already 32 bytes
+// (true for both Bitcoin txids and Ethereum tx hashes), or keccak256-hashed
+// down to 32 bytes as a fallback for any other shape.
+func sourceTxHashWord(txHash string) [32]byte {
+	var word [32]byte
+	if raw, err := hex.DecodeString(strings.TrimPrefix(txHash, "0x")); err == nil && len(raw) == wordSize {
+		copy(word[:], raw)
+		return word
+	}
+	copy(word[:], ethcrypto.Keccak256([]byte(txHash)))
+	return word
+}
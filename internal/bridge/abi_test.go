@@ -0,0 +1,80 @@
+package bridge
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEncodeMintCall(t *testing.T) {
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000aa")
+	amount := big.NewInt(1_500_000)
+	sourceTxHash := sourceTxHashWord(strings.Repeat("ab", 32))
+
+	data, err := encodeMintCall(recipient, amount, sourceTxHash)
+	if err != nil {
+		t.Fatalf("encodeMintCall: %v", err)
+	}
+	if len(data) != 4+3*wordSize {
+		t.Fatalf("data length = %d, want %d", len(data), 4+3*wordSize)
+	}
+
+	gotSelector := hex.EncodeToString(data[:4])
+	wantSelector := hex.EncodeToString(mintSelector)
+	if gotSelector != wantSelector {
+		t.Errorf("selector = %s, want %s", gotSelector, wantSelector)
+	}
+
+	addrWord := data[4 : 4+wordSize]
+	if !strings.EqualFold(hex.EncodeToString(addrWord[wordSize-20:]), recipient.Hex()[2:]) {
+		t.Errorf("recipient word = %x, want %s", addrWord, recipient.Hex())
+	}
+
+	amountWord := data[4+wordSize : 4+2*wordSize]
+	if new(big.Int).SetBytes(amountWord).Cmp(amount) != 0 {
+		t.Errorf("amount word = %x, want %s", amountWord, amount)
+	}
+
+	hashWord := data[4+2*wordSize:]
+	if !bytesEqual(hashWord, sourceTxHash[:]) {
+		t.Errorf("source tx hash word = %x, want %x", hashWord, sourceTxHash)
+	}
+}
+
+func TestEncodeMintCall_RejectsNegativeAmount(t *testing.T) {
+	_, err := encodeMintCall(common.Address{}, big.NewInt(-1), [32]byte{})
+	if err == nil {
+		t.Fatal("expected an error for a negative mint amount")
+	}
+}
+
+func TestSourceTxHashWord_ExactLengthHexIsDecodedDirectly(t *testing.T) {
+	txHash := "0x" + strings.Repeat("11", 32)
+	word := sourceTxHashWord(txHash)
+	want, _ := hex.DecodeString(strings.Repeat("11", 32))
+	if !bytesEqual(word[:], want) {
+		t.Errorf("word = %x, want %x", word, want)
+	}
+}
+
+func TestSourceTxHashWord_FallsBackToKeccakForOtherShapes(t *testing.T) {
+	word := sourceTxHashWord("not-a-hex-hash")
+	if word == ([32]byte{}) {
+		t.Fatal("expected a non-zero fallback hash")
+	}
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,253 @@
+package bridge
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/validate"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// maxCommitAttempts caps how many times the Committer retries a failed
+// intent before leaving it failed for manual investigation.
+const maxCommitAttempts = 5
+
+// Committer settles each pending BridgeIntent on its TargetChain: an EVM
+// mint() call for bitcoin->ethereum intents, or a Bitcoin withdrawal via the
+// PSBT pipeline for ethereum->bitcoin intents.
+type Committer struct {
+	db     *gorm.DB
+	rpc    ethRPC
+	wd     models.WithdrawalService // Bitcoin leg for ethereum->bitcoin intents; nil if unused
+	cfg    Config
+	signer *ecdsa.PrivateKey
+	from   common.Address
+	logger *logrus.Logger
+}
+
+// NewCommitter builds a Committer from cfg.ETHPrivateKey (a hex-encoded
+// secp256k1 key, 0x prefix optional). wd may be nil if this deployment never
+// bridges ethereum->bitcoin.
+func NewCommitter(db *gorm.DB, rpc ethRPC, wd models.WithdrawalService, cfg Config, logger *logrus.Logger) (*Committer, error) {
+	key, err := ethcrypto.HexToECDSA(strings.TrimPrefix(cfg.ETHPrivateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("bridge: parse BRIDGE_ETH_PRIV_KEY: %w", err)
+	}
+	return &Committer{
+		db:     db,
+		rpc:    rpc,
+		wd:     wd,
+		cfg:    cfg,
+		signer: key,
+		from:   ethcrypto.PubkeyToAddress(key.PublicKey),
+		logger: logger,
+	}, nil
+}
+
+// ProcessPending submits the target-chain transaction for every pending
+// intent, skipping (rather than resubmitting) any intent that already has a
+// TargetTxHash so a Committer restart can't double-mint or double-withdraw.
+func (c *Committer) ProcessPending(ctx context.Context) error {
+	var intents []models.BridgeIntent
+	err := c.db.Where("status = ? AND target_tx_hash = ?", models.StatusPending, "").Find(&intents).Error
+	if err != nil {
+		return fmt.Errorf("bridge: load pending intents: %w", err)
+	}
+
+	for i := range intents {
+		intent := &intents[i]
+		if commitErr := c.commit(ctx, intent); commitErr != nil {
+			c.logger.WithError(commitErr).WithField("intent_id", intent.ID).Warn("bridge: commit intent failed")
+			c.recordFailure(intent, commitErr)
+		}
+	}
+	return nil
+}
+
+func (c *Committer) commit(ctx context.Context, intent *models.BridgeIntent) error {
+	switch intent.TargetChain {
+	case models.CryptoTypeEthereum:
+		return c.mintOnEthereum(ctx, intent)
+	case models.CryptoTypeBitcoin:
+		return c.withdrawToBitcoin(intent)
+	default:
+		return fmt.Errorf("bridge: unsupported target chain %q", intent.TargetChain)
+	}
+}
+
+// mintOnEthereum signs and submits a mint(recipient, amount, sourceTxHash)
+// call against the configured bridge contract, then marks the intent
+// broadcasted; ConfirmBroadcasted later flips it to confirmed once the
+// TokensDeposited event shows up in the receipt.
+func (c *Committer) mintOnEthereum(ctx context.Context, intent *models.BridgeIntent) error {
+	ethValidator := validate.NewEthereumValidator(0)
+	if !ethValidator.ValidateAddress(intent.Recipient) {
+		return c.markFailed(intent, models.CryptoError{
+			Code:    models.ErrInvalidAddress,
+			Message: fmt.Sprintf("bridge: invalid mint recipient %q for intent %d", intent.Recipient, intent.ID),
+		})
+	}
+
+	amount, err := decimal.NewFromString(intent.Amount)
+	if err != nil {
+		return fmt.Errorf("bridge: parse intent %d amount %q: %w", intent.ID, intent.Amount, err)
+	}
+	data, err := encodeMintCall(common.HexToAddress(intent.Recipient), amount.Shift(18).BigInt(), sourceTxHashWord(intent.SourceTxHash))
+	if err != nil {
+		return fmt.Errorf("bridge: encode mint call for intent %d: %w", intent.ID, err)
+	}
+
+	nonce, err := c.rpc.PendingNonceAt(ctx, c.from)
+	if err != nil {
+		return fmt.Errorf("bridge: pending nonce for %s: %w", c.from.Hex(), err)
+	}
+	gasPrice, err := c.rpc.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("bridge: suggest gas price: %w", err)
+	}
+
+	to := common.HexToAddress(c.cfg.ContractAddress)
+	tx := ethtypes.NewTransaction(nonce, to, big.NewInt(0), c.cfg.GasLimit, gasPrice, data)
+	signedTx, err := ethtypes.SignTx(tx, ethtypes.NewEIP155Signer(new(big.Int).SetUint64(c.cfg.ChainID)), c.signer)
+	if err != nil {
+		return fmt.Errorf("bridge: sign mint tx for intent %d: %w", intent.ID, err)
+	}
+	if err := c.rpc.SendTransaction(ctx, signedTx); err != nil {
+		return fmt.Errorf("bridge: submit mint tx for intent %d: %w", intent.ID, err)
+	}
+
+	return c.db.Model(&models.BridgeIntent{}).Where("id = ? AND target_tx_hash = ?", intent.ID, "").
+		Updates(map[string]interface{}{"target_tx_hash": signedTx.Hash().Hex(), "status": models.StatusBroadcasted}).Error
+}
+
+// withdrawToBitcoin routes the intent through the same coin-selection/PSBT
+// pipeline a regular user withdrawal uses, spending from the bridge's own
+// reserve address.
+func (c *Committer) withdrawToBitcoin(intent *models.BridgeIntent) error {
+	if c.wd == nil {
+		return fmt.Errorf("bridge: no Bitcoin withdrawal service configured for intent %d", intent.ID)
+	}
+	if c.cfg.BridgeBTCAddressID == 0 {
+		return fmt.Errorf("bridge: BRIDGE_BTC_ADDRESS_ID not configured, cannot withdraw intent %d", intent.ID)
+	}
+	amount, err := decimal.NewFromString(intent.Amount)
+	if err != nil {
+		return fmt.Errorf("bridge: parse intent %d amount %q: %w", intent.ID, intent.Amount, err)
+	}
+
+	withdrawal, err := c.wd.CreateWithdrawal(c.cfg.BridgeBTCAddressID, intent.Recipient, amount.Shift(8).BigInt())
+	if err != nil {
+		return fmt.Errorf("bridge: create withdrawal for intent %d: %w", intent.ID, err)
+	}
+	if err := c.wd.ProcessWithdrawal(withdrawal.ID); err != nil {
+		return fmt.Errorf("bridge: process withdrawal %d for intent %d: %w", withdrawal.ID, intent.ID, err)
+	}
+	if err := c.wd.BroadcastWithdrawal(withdrawal.ID); err != nil {
+		return fmt.Errorf("bridge: broadcast withdrawal %d for intent %d: %w", withdrawal.ID, intent.ID, err)
+	}
+
+	var broadcasted models.CryptoWithdrawal
+	if err := c.db.First(&broadcasted, withdrawal.ID).Error; err != nil {
+		return fmt.Errorf("bridge: reload withdrawal %d: %w", withdrawal.ID, err)
+	}
+	return c.db.Model(&models.BridgeIntent{}).Where("id = ? AND target_tx_hash = ?", intent.ID, "").
+		Updates(map[string]interface{}{"target_tx_hash": broadcasted.TxHash, "status": models.StatusBroadcasted}).Error
+}
+
+// ConfirmBroadcasted checks every broadcasted intent's target-chain
+// transaction and flips it to confirmed once that transaction has settled.
+func (c *Committer) ConfirmBroadcasted(ctx context.Context) error {
+	var intents []models.BridgeIntent
+	if err := c.db.Where("status = ?", models.StatusBroadcasted).Find(&intents).Error; err != nil {
+		return fmt.Errorf("bridge: load broadcasted intents: %w", err)
+	}
+
+	for i := range intents {
+		intent := &intents[i]
+		var confirmed bool
+		var err error
+		switch intent.TargetChain {
+		case models.CryptoTypeEthereum:
+			confirmed, err = c.confirmMint(ctx, intent)
+		case models.CryptoTypeBitcoin:
+			confirmed, err = c.confirmWithdrawal(intent)
+		}
+		if err != nil {
+			c.logger.WithError(err).WithField("intent_id", intent.ID).Warn("bridge: confirm intent failed")
+			continue
+		}
+		if !confirmed {
+			continue
+		}
+		now := time.Now()
+		err = c.db.Model(&models.BridgeIntent{}).Where("id = ?", intent.ID).
+			Updates(map[string]interface{}{"status": models.StatusConfirmed, "completed_at": &now}).Error
+		if err != nil {
+			c.logger.WithError(err).WithField("intent_id", intent.ID).Warn("bridge: mark intent confirmed failed")
+		}
+	}
+	return nil
+}
+
+// confirmMint returns true once the mint tx's receipt is in, marking the
+// intent failed outright if the transaction reverted.
+func (c *Committer) confirmMint(ctx context.Context, intent *models.BridgeIntent) (bool, error) {
+	receipt, err := c.rpc.TransactionReceipt(ctx, common.HexToHash(intent.TargetTxHash))
+	if err != nil {
+		return false, nil // not mined yet; try again next poll
+	}
+	if receipt.Status == ethtypes.ReceiptStatusFailed {
+		return false, c.markFailed(intent, fmt.Errorf("bridge: mint tx %s reverted", intent.TargetTxHash))
+	}
+
+	sawDeposited := false
+	for _, log := range receipt.Logs {
+		if len(log.Topics) > 0 && log.Topics[0] == topicTokensDeposited {
+			sawDeposited = true
+			break
+		}
+	}
+	if !sawDeposited {
+		return false, c.markFailed(intent, fmt.Errorf("bridge: mint tx %s mined but TokensDeposited log not found", intent.TargetTxHash))
+	}
+	return true, nil
+}
+
+// confirmWithdrawal returns true once the linked CryptoWithdrawal has
+// reached StatusConfirmed (driven by the Bitcoin BlockchainMonitor).
+func (c *Committer) confirmWithdrawal(intent *models.BridgeIntent) (bool, error) {
+	var withdrawal models.CryptoWithdrawal
+	if err := c.db.Where("tx_hash = ?", intent.TargetTxHash).First(&withdrawal).Error; err != nil {
+		return false, fmt.Errorf("bridge: load withdrawal %s: %w", intent.TargetTxHash, err)
+	}
+	return withdrawal.Status == models.StatusConfirmed, nil
+}
+
+func (c *Committer) markFailed(intent *models.BridgeIntent, cause error) error {
+	return c.db.Model(&models.BridgeIntent{}).Where("id = ?", intent.ID).
+		Updates(map[string]interface{}{"status": models.StatusFailed, "failure_reason": cause.Error()}).Error
+}
+
+// recordFailure bumps an intent's attempt count, giving up (status failed)
+// once maxCommitAttempts is reached rather than retrying forever.
+func (c *Committer) recordFailure(intent *models.BridgeIntent, cause error) {
+	attempts := intent.Attempts + 1
+	status := models.StatusPending
+	if attempts >= maxCommitAttempts {
+		status = models.StatusFailed
+	}
+	update := map[string]interface{}{"attempts": attempts, "status": status, "failure_reason": cause.Error()}
+	if err := c.db.Model(&models.BridgeIntent{}).Where("id = ?", intent.ID).Updates(update).Error; err != nil {
+		c.logger.WithError(err).WithField("intent_id", intent.ID).Error("bridge: record intent failure failed")
+	}
+}
@@ -0,0 +1,159 @@
+package bridge
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// mockEthRPC is a stub EVM node: SendTransaction just records whatever it
+// was handed, and TransactionReceipt replays a canned receipt keyed by tx
+// hash, so tests can drive the mint/confirm flow without a real chain.
+type mockEthRPC struct {
+	sentTx   *ethtypes.Transaction
+	receipts map[common.Hash]*ethtypes.Receipt
+}
+
+func (m *mockEthRPC) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 7, nil
+}
+
+func (m *mockEthRPC) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(20_000_000_000), nil
+}
+
+func (m *mockEthRPC) SendTransaction(ctx context.Context, tx *ethtypes.Transaction) error {
+	m.sentTx = tx
+	return nil
+}
+
+func (m *mockEthRPC) TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error) {
+	receipt, ok := m.receipts[txHash]
+	if !ok {
+		return nil, errNotMined
+	}
+	return receipt, nil
+}
+
+var errNotMined = &mockError{"tx not mined"}
+
+type mockError struct{ msg string }
+
+func (e *mockError) Error() string { return e.msg }
+
+func newTestCommitter(t *testing.T, db *gorm.DB, rpc ethRPC) *Committer {
+	t.Helper()
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate test key: %v", err)
+	}
+	cfg := Config{
+		ContractAddress: "0x00000000000000000000000000000000001234",
+		ETHPrivateKey:   hex.EncodeToString(ethcrypto.FromECDSA(key)),
+		ChainID:         1337,
+		GasLimit:        200000,
+	}
+	committer, err := NewCommitter(db, rpc, nil, cfg, logrus.New())
+	if err != nil {
+		t.Fatalf("NewCommitter: %v", err)
+	}
+	return committer
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := models.AutoMigrate(db); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return db
+}
+
+func TestCommitter_ProcessPending_MintsOnEthereum(t *testing.T) {
+	db := newTestDB(t)
+	rpc := &mockEthRPC{receipts: map[common.Hash]*ethtypes.Receipt{}}
+	committer := newTestCommitter(t, db, rpc)
+
+	intent := models.BridgeIntent{
+		UserID:          1,
+		SourceChain:     models.CryptoTypeBitcoin,
+		SourceDepositID: 1,
+		SourceTxHash:    "aabbccdd",
+		Recipient:       "0x000000000000000000000000000000000000bb",
+		Amount:          "0.5",
+		TargetChain:     models.CryptoTypeEthereum,
+		Status:          models.StatusPending,
+	}
+	if err := db.Create(&intent).Error; err != nil {
+		t.Fatalf("seed intent: %v", err)
+	}
+
+	if err := committer.ProcessPending(context.Background()); err != nil {
+		t.Fatalf("ProcessPending: %v", err)
+	}
+	if rpc.sentTx == nil {
+		t.Fatal("expected a mint transaction to be submitted")
+	}
+
+	var reloaded models.BridgeIntent
+	if err := db.First(&reloaded, intent.ID).Error; err != nil {
+		t.Fatalf("reload intent: %v", err)
+	}
+	if reloaded.Status != models.StatusBroadcasted {
+		t.Errorf("status = %q, want %q", reloaded.Status, models.StatusBroadcasted)
+	}
+	if reloaded.TargetTxHash == "" {
+		t.Error("expected TargetTxHash to be recorded")
+	}
+}
+
+func TestCommitter_ConfirmBroadcasted_ConfirmsOnceReceiptSeen(t *testing.T) {
+	db := newTestDB(t)
+	txHash := common.HexToHash("0xdeadbeef")
+	rpc := &mockEthRPC{receipts: map[common.Hash]*ethtypes.Receipt{
+		txHash: {Status: ethtypes.ReceiptStatusSuccessful, Logs: []*ethtypes.Log{{Topics: []common.Hash{topicTokensDeposited}}}},
+	}}
+	committer := newTestCommitter(t, db, rpc)
+
+	intent := models.BridgeIntent{
+		UserID:          1,
+		SourceChain:     models.CryptoTypeBitcoin,
+		SourceDepositID: 2,
+		SourceTxHash:    "eeff0011",
+		Recipient:       "0x000000000000000000000000000000000000cc",
+		Amount:          "0.1",
+		TargetChain:     models.CryptoTypeEthereum,
+		TargetTxHash:    txHash.Hex(),
+		Status:          models.StatusBroadcasted,
+	}
+	if err := db.Create(&intent).Error; err != nil {
+		t.Fatalf("seed intent: %v", err)
+	}
+
+	if err := committer.ConfirmBroadcasted(context.Background()); err != nil {
+		t.Fatalf("ConfirmBroadcasted: %v", err)
+	}
+
+	var reloaded models.BridgeIntent
+	if err := db.First(&reloaded, intent.ID).Error; err != nil {
+		t.Fatalf("reload intent: %v", err)
+	}
+	if reloaded.Status != models.StatusConfirmed {
+		t.Errorf("status = %q, want %q", reloaded.Status, models.StatusConfirmed)
+	}
+	if reloaded.CompletedAt == nil {
+		t.Error("expected CompletedAt to be set")
+	}
+}
@@ -0,0 +1,75 @@
+// Package bridge implements a custodial BTC<->ETH bridge: an Indexer watches
+// confirmed deposits to either chain and enqueues a BridgeIntent for each,
+// and a Committer settles it on the other chain - minting wrapped tokens via
+// an EVM contract call for bitcoin->ethereum, or withdrawing BTC through the
+// PSBT pipeline for ethereum->bitcoin. Retries are keyed on the intent row so
+// a Committer restart never double-mints or double-withdraws.
+package bridge
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Config holds the bridge contract and hot-wallet settings, read from env.
+type Config struct {
+	ContractAddress string // bridge contract on Ethereum implementing mint(address,uint256,bytes32)
+	ETHRPCURL       string
+	ETHPrivateKey   string // hex-encoded hot wallet key, BRIDGE_ETH_PRIV_KEY
+	ChainID         uint64
+	GasLimit        uint64
+
+	// BridgeBTCAddressID is the CryptoAddress holding the bridge's Bitcoin
+	// liquidity reserve, spent from for ethereum->bitcoin intents.
+	BridgeBTCAddressID uint
+
+	RequiredConfirms uint
+}
+
+// LoadConfig reads BRIDGE_CONTRACT_ADDRESS, BRIDGE_ETH_RPC_URL,
+// BRIDGE_ETH_PRIV_KEY, BRIDGE_ETH_CHAIN_ID, BRIDGE_GAS_LIMIT, and
+// BRIDGE_BTC_ADDRESS_ID from the environment.
+func LoadConfig() (Config, error) {
+	rpcURL := os.Getenv("BRIDGE_ETH_RPC_URL")
+	if rpcURL == "" {
+		rpcURL = "http://127.0.0.1:8545"
+	}
+
+	chainIDStr := os.Getenv("BRIDGE_ETH_CHAIN_ID")
+	if chainIDStr == "" {
+		chainIDStr = "1"
+	}
+	chainID, err := strconv.ParseUint(chainIDStr, 10, 64)
+	if err != nil {
+		return Config{}, fmt.Errorf("bridge: invalid BRIDGE_ETH_CHAIN_ID %q: %w", chainIDStr, err)
+	}
+
+	gasLimitStr := os.Getenv("BRIDGE_GAS_LIMIT")
+	if gasLimitStr == "" {
+		gasLimitStr = "200000"
+	}
+	gasLimit, err := strconv.ParseUint(gasLimitStr, 10, 64)
+	if err != nil {
+		return Config{}, fmt.Errorf("bridge: invalid BRIDGE_GAS_LIMIT %q: %w", gasLimitStr, err)
+	}
+
+	var btcAddressID uint
+	if raw := os.Getenv("BRIDGE_BTC_ADDRESS_ID"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return Config{}, fmt.Errorf("bridge: invalid BRIDGE_BTC_ADDRESS_ID %q: %w", raw, err)
+		}
+		btcAddressID = uint(id)
+	}
+
+	return Config{
+		ContractAddress:    os.Getenv("BRIDGE_CONTRACT_ADDRESS"),
+		ETHRPCURL:          rpcURL,
+		ETHPrivateKey:      os.Getenv("BRIDGE_ETH_PRIV_KEY"),
+		ChainID:            chainID,
+		GasLimit:           gasLimit,
+		BridgeBTCAddressID: btcAddressID,
+		RequiredConfirms:   12,
+	}, nil
+}
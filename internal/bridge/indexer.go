@@ -0,0 +1,79 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Indexer watches confirmed deposits on one chain and enqueues a
+// BridgeIntent for each, once it has resolved a recipient address for the
+// same user on targetChain. A deposit with no matching address yet is left
+// for the next poll rather than failing outright.
+type Indexer struct {
+	db          *gorm.DB
+	logger      *logrus.Logger
+	sourceChain string
+	targetChain string
+}
+
+// NewIndexer builds an Indexer watching sourceChain deposits for bridging to
+// targetChain, e.g. NewIndexer(db, logger, models.CryptoTypeBitcoin, models.CryptoTypeEthereum).
+func NewIndexer(db *gorm.DB, logger *logrus.Logger, sourceChain, targetChain string) *Indexer {
+	return &Indexer{db: db, logger: logger, sourceChain: sourceChain, targetChain: targetChain}
+}
+
+// ScanDeposits enqueues a pending BridgeIntent for every confirmed
+// sourceChain deposit that doesn't have one yet.
+func (ix *Indexer) ScanDeposits(ctx context.Context) error {
+	var deposits []models.CryptoDeposit
+	err := ix.db.Where("crypto_type = ? AND status IN ?",
+		ix.sourceChain, []string{models.StatusConfirmed, models.StatusCredited}).
+		Find(&deposits).Error
+	if err != nil {
+		return fmt.Errorf("bridge: load confirmed %s deposits: %w", ix.sourceChain, err)
+	}
+
+	for _, deposit := range deposits {
+		if err := ix.enqueue(deposit); err != nil {
+			ix.logger.WithError(err).WithField("deposit_id", deposit.ID).Warn("bridge: enqueue intent failed")
+		}
+	}
+	return nil
+}
+
+// enqueue resolves deposit's destination address on targetChain and writes
+// a pending BridgeIntent row, ignoring the insert if one already exists for
+// this deposit (SourceDepositID is unique).
+func (ix *Indexer) enqueue(deposit models.CryptoDeposit) error {
+	var fromAddr models.CryptoAddress
+	if err := ix.db.First(&fromAddr, deposit.AddressID).Error; err != nil {
+		return fmt.Errorf("bridge: load deposit address %d: %w", deposit.AddressID, err)
+	}
+
+	var recipient models.CryptoAddress
+	err := ix.db.Where("user_id = ? AND crypto_type = ? AND is_active = ?", fromAddr.UserID, ix.targetChain, true).
+		First(&recipient).Error
+	if err != nil {
+		return fmt.Errorf("bridge: no active %s address for user %d yet: %w", ix.targetChain, fromAddr.UserID, err)
+	}
+
+	intent := models.BridgeIntent{
+		UserID:          fromAddr.UserID,
+		SourceChain:     ix.sourceChain,
+		SourceDepositID: deposit.ID,
+		SourceTxHash:    deposit.TxHash,
+		Recipient:       recipient.Address,
+		Amount:          deposit.Amount,
+		TargetChain:     ix.targetChain,
+		Status:          models.StatusPending,
+	}
+	if err := ix.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&intent).Error; err != nil {
+		return fmt.Errorf("bridge: enqueue intent for deposit %d: %w", deposit.ID, err)
+	}
+	return nil
+}
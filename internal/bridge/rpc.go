@@ -0,0 +1,36 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ethRPC is the subset of an Ethereum JSON-RPC client the Committer needs to
+// sign, submit, and confirm a mint transaction; it exists so tests can
+// substitute a mock node.
+type ethRPC interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *ethtypes.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error)
+}
+
+// RPCClient wraps go-ethereum's ethclient with the narrower ethRPC surface
+// this package needs.
+type RPCClient struct {
+	*ethclient.Client
+}
+
+// NewRPCClient dials the configured Ethereum JSON-RPC endpoint.
+func NewRPCClient(cfg Config) (*RPCClient, error) {
+	client, err := ethclient.Dial(cfg.ETHRPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("bridge: dial %s: %w", cfg.ETHRPCURL, err)
+	}
+	return &RPCClient{Client: client}, nil
+}
@@ -0,0 +1,56 @@
+// Package bitcoin implements models.BlockchainMonitor for Bitcoin Core: a
+// JSON-RPC scanner with reorg detection, an optional ZMQ feed for low
+// latency notifications, and a small mempool cache for 0-conf deposits.
+package bitcoin
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Config holds the Bitcoin Core RPC/ZMQ connection settings, read from env.
+type Config struct {
+	RPCHost     string
+	RPCPort     int
+	RPCUser     string
+	RPCPass     string
+	NetworkName string // mainnet, testnet, regtest
+	ZMQEndpoint string // e.g. tcp://127.0.0.1:28332; empty disables ZMQ
+
+	RequiredConfirms uint
+	ChainTipDepth    int // how many recent block hashes to retain for reorg detection
+}
+
+// LoadConfig reads BITCOIN_RPC_HOST/PORT/USER/PASS/NETWORK_NAME and
+// BITCOIN_ZMQ_ENDPOINT from the environment.
+func LoadConfig() (Config, error) {
+	host := os.Getenv("BITCOIN_RPC_HOST")
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	portStr := os.Getenv("BITCOIN_RPC_PORT")
+	if portStr == "" {
+		portStr = "8332"
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return Config{}, fmt.Errorf("bitcoin: invalid BITCOIN_RPC_PORT %q: %w", portStr, err)
+	}
+
+	network := os.Getenv("BITCOIN_RPC_NETWORK_NAME")
+	if network == "" {
+		network = "mainnet"
+	}
+
+	return Config{
+		RPCHost:          host,
+		RPCPort:          port,
+		RPCUser:          os.Getenv("BITCOIN_RPC_USER"),
+		RPCPass:          os.Getenv("BITCOIN_RPC_PASS"),
+		NetworkName:      network,
+		ZMQEndpoint:      os.Getenv("BITCOIN_ZMQ_ENDPOINT"),
+		RequiredConfirms: 6,
+		ChainTipDepth:    100,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package bitcoin
+
+import "sync"
+
+// mempoolTx is a minimal 0-conf transaction kept so unconfirmed deposits can
+// surface immediately, before they are ever seen in a block.
+type mempoolTx struct {
+	txid   string
+	toAddr string
+	amount float64
+	vout   uint
+}
+
+// mempoolCache is an in-memory cache of unconfirmed transactions touching
+// watched addresses. It is cleared of an entry as soon as that transaction
+// is seen confirmed in a block.
+type mempoolCache struct {
+	mu  sync.RWMutex
+	txs map[string]mempoolTx // keyed by txid
+}
+
+func newMempoolCache() *mempoolCache {
+	return &mempoolCache{txs: make(map[string]mempoolTx)}
+}
+
+func (m *mempoolCache) put(tx mempoolTx) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.txs[tx.txid] = tx
+}
+
+func (m *mempoolCache) remove(txid string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.txs, txid)
+}
+
+func (m *mempoolCache) get(txid string) (mempoolTx, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tx, ok := m.txs[txid]
+	return tx, ok
+}
+
+// forAddress returns all cached 0-conf transactions paying a given address.
+func (m *mempoolCache) forAddress(address string) []mempoolTx {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []mempoolTx
+	for _, tx := range m.txs {
+		if tx.toAddr == address {
+			out = append(out, tx)
+		}
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package bitcoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// recordTip persists a freshly processed block as the new chain_tip row and
+// prunes entries older than cfg.ChainTipDepth.
+func (m *Monitor) recordTip(height uint64, hash, prevHash string) error {
+	tip := models.ChainTip{
+		Network:    m.cfg.NetworkName,
+		CryptoType: models.CryptoTypeBitcoin,
+		Height:     height,
+		BlockHash:  hash,
+		PrevHash:   prevHash,
+	}
+	if err := m.db.Create(&tip).Error; err != nil {
+		return fmt.Errorf("bitcoin: record chain tip: %w", err)
+	}
+
+	var pruneBefore uint64
+	if height > uint64(m.cfg.ChainTipDepth) {
+		pruneBefore = height - uint64(m.cfg.ChainTipDepth)
+	}
+	return m.db.Where("network = ? AND crypto_type = ? AND height < ?", m.cfg.NetworkName, models.CryptoTypeBitcoin, pruneBefore).
+		Delete(&models.ChainTip{}).Error
+}
+
+// localTip returns the highest non-orphaned chain_tip row we have recorded.
+func (m *Monitor) localTip() (*models.ChainTip, error) {
+	var tip models.ChainTip
+	err := m.db.Where("network = ? AND crypto_type = ? AND is_orphaned = ?", m.cfg.NetworkName, models.CryptoTypeBitcoin, false).
+		Order("height DESC").
+		First(&tip).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("bitcoin: load local tip: %w", err)
+	}
+	return &tip, nil
+}
+
+// detectAndHandleReorg walks the node's chain back from the current tip
+// until it finds a block hash that matches our local chain_tip history
+// (the common ancestor), marking every local tip above that height as
+// orphaned along with the deposits/UTXOs it produced. It returns the
+// height to resume scanning from (the common ancestor height + 1).
+func (m *Monitor) detectAndHandleReorg(ctx context.Context, nodeHeight uint64) (uint64, error) {
+	local, err := m.localTip()
+	if err != nil {
+		return 0, err
+	}
+	if local == nil {
+		return 0, nil // nothing recorded yet; start from genesis-ish (height 0 is handled by caller)
+	}
+
+	nodeHashAtLocalHeight, err := m.rpc.GetBlockHash(ctx, local.Height)
+	if err != nil {
+		return 0, fmt.Errorf("bitcoin: reorg check getblockhash(%d): %w", local.Height, err)
+	}
+	if nodeHashAtLocalHeight == local.BlockHash {
+		return local.Height + 1, nil // no reorg; resume right after our tip
+	}
+
+	m.logger.Warnf("bitcoin: reorg detected at/above height %d, walking back to find common ancestor", local.Height)
+
+	cursor := local
+	for {
+		var parent models.ChainTip
+		err := m.db.Where("network = ? AND crypto_type = ? AND height = ?", m.cfg.NetworkName, models.CryptoTypeBitcoin, cursor.Height-1).
+			First(&parent).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) || cursor.Height == 0 {
+			return 0, nil // reorg deeper than our retained history; rescan from genesis
+		}
+		if err != nil {
+			return 0, fmt.Errorf("bitcoin: load parent tip: %w", err)
+		}
+
+		nodeHash, err := m.rpc.GetBlockHash(ctx, parent.Height)
+		if err != nil {
+			return 0, fmt.Errorf("bitcoin: reorg check getblockhash(%d): %w", parent.Height, err)
+		}
+		if nodeHash == parent.BlockHash {
+			if err := m.orphanAbove(parent.Height); err != nil {
+				return 0, err
+			}
+			return parent.Height + 1, nil
+		}
+		cursor = &parent
+	}
+}
+
+// orphanAbove marks every local chain_tip above the common-ancestor height
+// as orphaned, along with the BitcoinDeposit/CryptoUTXO rows that came from
+// those blocks. Orphaned UTXOs are flagged IsOrphaned, never un-spent: a
+// coin spent by an in-flight/confirmed withdrawal must stay excluded from
+// selection even if the block that confirmed it gets reorged out.
+func (m *Monitor) orphanAbove(commonAncestorHeight uint64) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ChainTip{}).
+			Where("network = ? AND crypto_type = ? AND height > ?", m.cfg.NetworkName, models.CryptoTypeBitcoin, commonAncestorHeight).
+			Update("is_orphaned", true).Error; err != nil {
+			return fmt.Errorf("bitcoin: orphan chain tips: %w", err)
+		}
+		if err := tx.Model(&models.CryptoDeposit{}).
+			Where("crypto_type = ? AND network = ? AND block_height > ?", models.CryptoTypeBitcoin, m.cfg.NetworkName, commonAncestorHeight).
+			Update("status", models.StatusOrphaned).Error; err != nil {
+			return fmt.Errorf("bitcoin: orphan deposits: %w", err)
+		}
+		return tx.Model(&models.CryptoUTXO{}).
+			Where("crypto_type = ? AND network = ? AND block_height > ?", models.CryptoTypeBitcoin, m.cfg.NetworkName, commonAncestorHeight).
+			Update("is_orphaned", true).Error
+	})
+}
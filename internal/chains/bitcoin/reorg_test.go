@@ -0,0 +1,169 @@
+package bitcoin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// mockRPC is a regtest-style fixture stream: a fixed set of block hashes
+// indexed by height, with GetBlockHash/GetBlockCount serving whatever the
+// test has currently set as the node's view of the chain.
+type mockRPC struct {
+	coreRPC
+	hashesByHeight map[uint64]string
+	blocksByHash   map[string]*Block
+	tipHeight      uint64
+}
+
+func (m *mockRPC) GetBlockCount(ctx context.Context) (uint64, error) {
+	return m.tipHeight, nil
+}
+
+func (m *mockRPC) GetBlockHash(ctx context.Context, height uint64) (string, error) {
+	hash, ok := m.hashesByHeight[height]
+	if !ok {
+		return "", fmt.Errorf("no block at height %d", height)
+	}
+	return hash, nil
+}
+
+func (m *mockRPC) GetBlock(ctx context.Context, hash string) (*Block, error) {
+	block, ok := m.blocksByHash[hash]
+	if !ok {
+		return nil, fmt.Errorf("no block %s", hash)
+	}
+	return block, nil
+}
+
+func newTestMonitor(t *testing.T, rpc coreRPC) *Monitor {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := models.AutoMigrate(db); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return &Monitor{
+		db:      db,
+		rpc:     rpc,
+		cfg:     Config{NetworkName: models.NetworkRegtest, RequiredConfirms: 6, ChainTipDepth: 100},
+		logger:  logrus.New(),
+		mempool: newMempoolCache(),
+	}
+}
+
+func TestDetectAndHandleReorg_NoLocalHistory(t *testing.T) {
+	rpc := &mockRPC{hashesByHeight: map[uint64]string{}, blocksByHash: map[string]*Block{}, tipHeight: 5}
+	mon := newTestMonitor(t, rpc)
+
+	resumeFrom, err := mon.detectAndHandleReorg(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("detectAndHandleReorg: %v", err)
+	}
+	if resumeFrom != 0 {
+		t.Fatalf("expected to resume from height 0 with no local history, got %d", resumeFrom)
+	}
+}
+
+func TestDetectAndHandleReorg_NoReorg(t *testing.T) {
+	rpc := &mockRPC{hashesByHeight: map[uint64]string{10: "hash-10"}, tipHeight: 10}
+	mon := newTestMonitor(t, rpc)
+	if err := mon.recordTip(10, "hash-10", "hash-9"); err != nil {
+		t.Fatalf("recordTip: %v", err)
+	}
+
+	resumeFrom, err := mon.detectAndHandleReorg(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("detectAndHandleReorg: %v", err)
+	}
+	if resumeFrom != 11 {
+		t.Fatalf("expected to resume from height 11, got %d", resumeFrom)
+	}
+}
+
+func TestDetectAndHandleReorg_WalksBackAndOrphans(t *testing.T) {
+	rpc := &mockRPC{hashesByHeight: map[uint64]string{
+		8:  "hash-8",     // common ancestor, unchanged
+		9:  "hash-9-new", // node has a different block at 9 now
+		10: "hash-10-new",
+	}, tipHeight: 10}
+	mon := newTestMonitor(t, rpc)
+
+	if err := mon.recordTip(8, "hash-8", "hash-7"); err != nil {
+		t.Fatalf("recordTip(8): %v", err)
+	}
+	if err := mon.recordTip(9, "hash-9-old", "hash-8"); err != nil {
+		t.Fatalf("recordTip(9): %v", err)
+	}
+	if err := mon.recordTip(10, "hash-10-old", "hash-9-old"); err != nil {
+		t.Fatalf("recordTip(10): %v", err)
+	}
+	if err := mon.db.Create(&models.CryptoDeposit{
+		TxHash: "orphaned-deposit", IdempotencyKey: "orphaned-deposit:0", Amount: "1", BlockHeight: 9,
+		Network: models.NetworkRegtest, CryptoType: models.CryptoTypeBitcoin, Status: models.StatusPending,
+	}).Error; err != nil {
+		t.Fatalf("seed deposit: %v", err)
+	}
+	if err := mon.db.Create(&models.CryptoUTXO{
+		TxHash: "orphaned-utxo", Vout: 0, Amount: "1", BlockHeight: 9, IsSpent: true,
+		Network: models.NetworkRegtest, CryptoType: models.CryptoTypeBitcoin,
+	}).Error; err != nil {
+		t.Fatalf("seed utxo: %v", err)
+	}
+	if err := mon.db.Create(&models.CryptoUTXO{
+		TxHash: "mainnet-utxo-same-height", Vout: 0, Amount: "1", BlockHeight: 9, IsSpent: true,
+		Network: models.NetworkMainnet, CryptoType: models.CryptoTypeBitcoin,
+	}).Error; err != nil {
+		t.Fatalf("seed other-network utxo: %v", err)
+	}
+
+	resumeFrom, err := mon.detectAndHandleReorg(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("detectAndHandleReorg: %v", err)
+	}
+	if resumeFrom != 9 {
+		t.Fatalf("expected to resume from height 9 (common ancestor 8 + 1), got %d", resumeFrom)
+	}
+
+	var deposit models.CryptoDeposit
+	if err := mon.db.Where("tx_hash = ?", "orphaned-deposit").First(&deposit).Error; err != nil {
+		t.Fatalf("load deposit: %v", err)
+	}
+	if deposit.Status != models.StatusOrphaned {
+		t.Fatalf("expected deposit to be marked orphaned, got %q", deposit.Status)
+	}
+
+	var tip9 models.ChainTip
+	if err := mon.db.Where("height = ?", 9).First(&tip9).Error; err != nil {
+		t.Fatalf("load tip 9: %v", err)
+	}
+	if !tip9.IsOrphaned {
+		t.Fatalf("expected chain tip at height 9 to be orphaned")
+	}
+
+	var utxo models.CryptoUTXO
+	if err := mon.db.Where("tx_hash = ?", "orphaned-utxo").First(&utxo).Error; err != nil {
+		t.Fatalf("load utxo: %v", err)
+	}
+	if !utxo.IsOrphaned {
+		t.Fatalf("expected utxo to be marked orphaned")
+	}
+	if !utxo.IsSpent {
+		t.Fatalf("orphaning must never un-spend a utxo that was already spent")
+	}
+
+	var otherNetworkUTXO models.CryptoUTXO
+	if err := mon.db.Where("tx_hash = ?", "mainnet-utxo-same-height").First(&otherNetworkUTXO).Error; err != nil {
+		t.Fatalf("load other-network utxo: %v", err)
+	}
+	if otherNetworkUTXO.IsOrphaned {
+		t.Fatalf("reorg on regtest must not orphan a mainnet utxo at the same height")
+	}
+}
@@ -0,0 +1,225 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RPCClient is a minimal Bitcoin Core JSON-RPC client covering the calls
+// the scanner and withdrawal pipeline need.
+type RPCClient struct {
+	endpoint   string
+	user, pass string
+	httpClient *http.Client
+}
+
+// NewRPCClient builds a client from a loaded Config.
+func NewRPCClient(cfg Config) *RPCClient {
+	return &RPCClient{
+		endpoint: fmt.Sprintf("http://%s:%d", cfg.RPCHost, cfg.RPCPort),
+		user:     cfg.RPCUser,
+		pass:     cfg.RPCPass,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      string        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+	ID     string          `json:"id"`
+}
+
+// call performs a single JSON-RPC request and decodes the result into out.
+func (c *RPCClient) call(ctx context.Context, method string, params []interface{}, out interface{}) error {
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "1.0",
+		ID:      "crypto_trading_v2",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("bitcoin rpc: marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("bitcoin rpc: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.user, c.pass)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("bitcoin rpc: %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("bitcoin rpc: %s: decode response: %w", method, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("bitcoin rpc: %s: %s (code %d)", method, rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return fmt.Errorf("bitcoin rpc: %s: decode result: %w", method, err)
+	}
+	return nil
+}
+
+// GetBlockCount returns the current chain height.
+func (c *RPCClient) GetBlockCount(ctx context.Context) (uint64, error) {
+	var height uint64
+	err := c.call(ctx, "getblockcount", nil, &height)
+	return height, err
+}
+
+// GetBlockHash returns the hash of the block at a given height.
+func (c *RPCClient) GetBlockHash(ctx context.Context, height uint64) (string, error) {
+	var hash string
+	err := c.call(ctx, "getblockhash", []interface{}{height}, &hash)
+	return hash, err
+}
+
+// Vin is a transaction input as returned by getblock verbosity=2.
+type Vin struct {
+	TxID string `json:"txid"`
+	Vout uint   `json:"vout"`
+}
+
+// Vout is a transaction output as returned by getblock verbosity=2.
+type Vout struct {
+	Value        float64 `json:"value"`
+	N            uint    `json:"n"`
+	ScriptPubKey struct {
+		Hex       string   `json:"hex"`
+		Address   string   `json:"address"`
+		Addresses []string `json:"addresses"`
+		Type      string   `json:"type"`
+	} `json:"scriptPubKey"`
+}
+
+// Tx is a decoded transaction as embedded in getblock verbosity=2.
+type Tx struct {
+	TxID     string `json:"txid"`
+	Hash     string `json:"hash"`
+	Size     uint   `json:"size"`
+	VSize    uint   `json:"vsize"`
+	Weight   uint   `json:"weight"`
+	Version  uint   `json:"version"`
+	LockTime uint   `json:"locktime"`
+	Vin      []Vin  `json:"vin"`
+	Vout     []Vout `json:"vout"`
+	Hex      string `json:"hex"`
+}
+
+// Block is the getblock verbosity=2 result: full transactions, not just hashes.
+type Block struct {
+	Hash              string `json:"hash"`
+	Confirmations     int64  `json:"confirmations"`
+	Height            uint64 `json:"height"`
+	Time              int64  `json:"time"`
+	PreviousBlockHash string `json:"previousblockhash"`
+	Tx                []Tx   `json:"tx"`
+}
+
+// GetBlock fetches a block with fully decoded transactions (verbosity=2).
+func (c *RPCClient) GetBlock(ctx context.Context, hash string) (*Block, error) {
+	var block Block
+	if err := c.call(ctx, "getblock", []interface{}{hash, 2}, &block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// GetRawTransaction fetches a single decoded transaction by hash.
+func (c *RPCClient) GetRawTransaction(ctx context.Context, txid string) (*Tx, error) {
+	var tx Tx
+	if err := c.call(ctx, "getrawtransaction", []interface{}{txid, true}, &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// DecodeRawTransaction decodes a raw (unconfirmed) transaction hex blob,
+// typically one received over the ZMQ rawtx feed, without requiring it to
+// already be known to the node's wallet or mempool index.
+func (c *RPCClient) DecodeRawTransaction(ctx context.Context, rawTxHex string) (*Tx, error) {
+	var tx Tx
+	if err := c.call(ctx, "decoderawtransaction", []interface{}{rawTxHex}, &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// SendRawTransaction broadcasts a signed, hex-encoded raw transaction and
+// returns its txid.
+func (c *RPCClient) SendRawTransaction(ctx context.Context, rawTxHex string) (string, error) {
+	var txid string
+	err := c.call(ctx, "sendrawtransaction", []interface{}{rawTxHex}, &txid)
+	return txid, err
+}
+
+// FeeEstimate is the result of estimatesmartfee: a BTC/kvB fee rate.
+type FeeEstimate struct {
+	FeeRate float64  `json:"feerate"` // BTC per kvB
+	Errors  []string `json:"errors,omitempty"`
+	Blocks  int      `json:"blocks"`
+}
+
+// EstimateSmartFee asks Bitcoin Core for a fee estimate targeting
+// confirmation within confTarget blocks.
+func (c *RPCClient) EstimateSmartFee(ctx context.Context, confTarget int) (*FeeEstimate, error) {
+	var estimate FeeEstimate
+	if err := c.call(ctx, "estimatesmartfee", []interface{}{confTarget}, &estimate); err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
+
+// ImportDescriptor is one entry of an importdescriptors request.
+type ImportDescriptor struct {
+	Descriptor string      `json:"desc"`
+	Timestamp  interface{} `json:"timestamp"` // unix seconds or "now"
+	Label      string      `json:"label,omitempty"`
+	Watchonly  bool        `json:"watchonly"`
+}
+
+// ImportDescriptors registers watch-only output descriptors (e.g. newly
+// derived HD addresses) with the node so its wallet indexes them.
+func (c *RPCClient) ImportDescriptors(ctx context.Context, descriptors []ImportDescriptor) error {
+	var results []struct {
+		Success bool      `json:"success"`
+		Error   *rpcError `json:"error,omitempty"`
+	}
+	if err := c.call(ctx, "importdescriptors", []interface{}{descriptors}, &results); err != nil {
+		return err
+	}
+	for i, r := range results {
+		if !r.Success {
+			return fmt.Errorf("bitcoin rpc: importdescriptors[%d] failed: %+v", i, r.Error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,351 @@
+package bitcoin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// nativeCurrencySymbol is the Account currency credited for a confirmed
+// Bitcoin deposit.
+const nativeCurrencySymbol = "BTC"
+
+// balanceCreditGuardTopic namespaces the EventOutbox rows creditAccountBalance
+// inserts purely as an idempotency guard; it is never dispatched downstream.
+const balanceCreditGuardTopic = "bitcoin.balance-credit.guard"
+
+// EventPublisher is the minimal hook the monitor calls on deposit/
+// confirmation state changes. internal/events provides a Kafka-backed
+// implementation; nil is a valid no-op. tx is the same transaction the
+// deposit row was just written in, so an outbox-pattern implementation can
+// enqueue atomically with the state change; idempotencyKey is tx_hash+vout.
+type EventPublisher interface {
+	PublishDepositSeen(ctx context.Context, tx *gorm.DB, idempotencyKey string, deposit *models.CryptoDeposit) error
+	PublishConfirmation(ctx context.Context, tx *gorm.DB, idempotencyKey string, deposit *models.CryptoDeposit) error
+	PublishBalanceChanged(ctx context.Context, tx *gorm.DB, idempotencyKey string, account *models.Account) error
+}
+
+// coreRPC is the subset of RPCClient the monitor depends on; it exists so
+// tests can substitute a mock Bitcoin Core.
+type coreRPC interface {
+	GetBlockCount(ctx context.Context) (uint64, error)
+	GetBlockHash(ctx context.Context, height uint64) (string, error)
+	GetBlock(ctx context.Context, hash string) (*Block, error)
+	GetRawTransaction(ctx context.Context, txid string) (*Tx, error)
+	DecodeRawTransaction(ctx context.Context, rawTxHex string) (*Tx, error)
+	SendRawTransaction(ctx context.Context, rawTxHex string) (string, error)
+	EstimateSmartFee(ctx context.Context, confTarget int) (*FeeEstimate, error)
+}
+
+// Monitor implements models.BlockchainMonitor against Bitcoin Core.
+type Monitor struct {
+	db     *gorm.DB
+	rpc    coreRPC
+	cfg    Config
+	logger *logrus.Logger
+
+	mempool   *mempoolCache
+	publisher EventPublisher
+}
+
+// NewMonitor builds a Monitor. publisher may be nil.
+func NewMonitor(db *gorm.DB, cfg Config, logger *logrus.Logger, publisher EventPublisher) *Monitor {
+	return &Monitor{
+		db:        db,
+		rpc:       NewRPCClient(cfg),
+		cfg:       cfg,
+		logger:    logger,
+		mempool:   newMempoolCache(),
+		publisher: publisher,
+	}
+}
+
+// GetLatestBlockHeight returns the node's current chain height.
+func (m *Monitor) GetLatestBlockHeight() (uint64, error) {
+	return m.rpc.GetBlockCount(context.Background())
+}
+
+// ScanNewBlocks walks forward from our last recorded chain tip to the
+// node's current height, handling any reorg found along the way, and
+// processes every new block in order.
+func (m *Monitor) ScanNewBlocks(ctx context.Context) error {
+	nodeHeight, err := m.rpc.GetBlockCount(ctx)
+	if err != nil {
+		return fmt.Errorf("bitcoin: getblockcount: %w", err)
+	}
+
+	resumeFrom, err := m.detectAndHandleReorg(ctx, nodeHeight)
+	if err != nil {
+		return err
+	}
+
+	for height := resumeFrom; height <= nodeHeight; height++ {
+		if err := m.ProcessBlock(height); err != nil {
+			return fmt.Errorf("bitcoin: process block %d: %w", height, err)
+		}
+	}
+
+	return m.advanceConfirmations(ctx, nodeHeight)
+}
+
+// ProcessBlock fetches and ingests a single block by height: it extracts
+// vouts paying watched addresses, records BitcoinTransaction/CryptoUTXO
+// rows, and appends the chain tip entry used for reorg detection.
+func (m *Monitor) ProcessBlock(blockHeight uint64) error {
+	ctx := context.Background()
+	hash, err := m.rpc.GetBlockHash(ctx, blockHeight)
+	if err != nil {
+		return fmt.Errorf("bitcoin: getblockhash(%d): %w", blockHeight, err)
+	}
+	block, err := m.rpc.GetBlock(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("bitcoin: getblock(%s): %w", hash, err)
+	}
+
+	watched, err := m.watchedAddresses()
+	if err != nil {
+		return err
+	}
+
+	blockTime := time.Unix(block.Time, 0).UTC()
+	for _, tx := range block.Tx {
+		m.mempool.remove(tx.TxID)
+		for _, vout := range tx.Vout {
+			addr := vout.ScriptPubKey.Address
+			if addr == "" && len(vout.ScriptPubKey.Addresses) > 0 {
+				addr = vout.ScriptPubKey.Addresses[0]
+			}
+			addressID, ok := watched[addr]
+			if !ok {
+				continue
+			}
+			if err := m.creditOutput(ctx, addressID, addr, tx, vout, block, blockTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	return m.recordTip(blockHeight, block.Hash, block.PreviousBlockHash)
+}
+
+// creditOutput inserts the CryptoTransaction/CryptoUTXO/CryptoDeposit rows
+// for a single vout paying one of our watched addresses.
+func (m *Monitor) creditOutput(ctx context.Context, addressID uint, addr string, tx Tx, vout Vout, block *Block, blockTime time.Time) error {
+	amount := fmt.Sprintf("%.8f", vout.Value)
+
+	return m.db.Transaction(func(gtx *gorm.DB) error {
+		transaction := models.CryptoTransaction{
+			AddressID:   addressID,
+			TxHash:      tx.TxID,
+			BlockHash:   block.Hash,
+			BlockHeight: block.Height,
+			BlockTime:   &blockTime,
+			ToAddress:   addr,
+			Amount:      amount,
+			Status:      models.StatusConfirmed,
+			Network:     m.cfg.NetworkName,
+			CryptoType:  models.CryptoTypeBitcoin,
+			Direction:   models.DirectionIncoming,
+		}
+		if err := gtx.Where("tx_hash = ?", tx.TxID).FirstOrCreate(&transaction).Error; err != nil {
+			return fmt.Errorf("bitcoin: persist transaction: %w", err)
+		}
+
+		utxo := models.CryptoUTXO{
+			AddressID:    addressID,
+			TxHash:       tx.TxID,
+			Vout:         vout.N,
+			Amount:       amount,
+			ScriptPubKey: vout.ScriptPubKey.Hex,
+			BlockHeight:  block.Height,
+			Network:      m.cfg.NetworkName,
+			CryptoType:   models.CryptoTypeBitcoin,
+		}
+		if err := gtx.Where("tx_hash = ? AND vout = ?", tx.TxID, vout.N).FirstOrCreate(&utxo).Error; err != nil {
+			return fmt.Errorf("bitcoin: persist utxo: %w", err)
+		}
+
+		idempotencyKey := fmt.Sprintf("%s:%d", tx.TxID, vout.N)
+		deposit := models.CryptoDeposit{
+			AddressID:        addressID,
+			TxHash:           tx.TxID,
+			IdempotencyKey:   idempotencyKey,
+			Amount:           amount,
+			RequiredConfirms: m.cfg.RequiredConfirms,
+			Status:           models.StatusPending,
+			BlockHeight:      block.Height,
+			BlockTime:        &blockTime,
+			Network:          m.cfg.NetworkName,
+			CryptoType:       models.CryptoTypeBitcoin,
+			Currency:         nativeCurrencySymbol,
+		}
+		if err := gtx.Where("idempotency_key = ?", idempotencyKey).FirstOrCreate(&deposit).Error; err != nil {
+			return fmt.Errorf("bitcoin: persist deposit: %w", err)
+		}
+
+		if m.publisher != nil {
+			if err := m.publisher.PublishDepositSeen(ctx, gtx, idempotencyKey, &deposit); err != nil {
+				m.logger.WithError(err).Warn("bitcoin: publish deposit-seen event failed")
+			}
+		}
+		return nil
+	})
+}
+
+// creditAccountBalance credits the Spot account backing a confirmed deposit's
+// address, guarded by an EventOutbox row keyed on idempotencyKey so a retried
+// or re-delivered call never double-credits. Mirrors
+// internal/chains/ethereum.Monitor.creditAccountBalance.
+func (m *Monitor) creditAccountBalance(ctx context.Context, tx *gorm.DB, addressID uint, currency, amount, idempotencyKey string) error {
+	guard := models.EventOutbox{
+		Topic:          balanceCreditGuardTopic,
+		IdempotencyKey: idempotencyKey,
+		EventType:      "bitcoin.balance.credited",
+		Payload:        "{}",
+		Status:         models.EventOutboxStatusSent,
+	}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&guard)
+	if result.Error != nil {
+		return fmt.Errorf("bitcoin: record balance-credit guard: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	var address models.CryptoAddress
+	if err := tx.First(&address, addressID).Error; err != nil {
+		return fmt.Errorf("bitcoin: load address %d: %w", addressID, err)
+	}
+	delta, err := decimal.NewFromString(amount)
+	if err != nil {
+		return fmt.Errorf("bitcoin: parse credit amount %q: %w", amount, err)
+	}
+
+	var account models.Account
+	err = tx.Where("user_id = ? AND currency = ? AND type = ?",
+		fmt.Sprint(address.UserID), currency, models.AccountTypeSpot).First(&account).Error
+	if err != nil {
+		account = models.Account{
+			UserID:   fmt.Sprint(address.UserID),
+			Type:     models.AccountTypeSpot,
+			Currency: currency,
+		}
+		if err := tx.Create(&account).Error; err != nil {
+			return fmt.Errorf("bitcoin: create account: %w", err)
+		}
+	}
+	account.Balance = account.Balance.Add(delta)
+	account.AvailableBalance = account.AvailableBalance.Add(delta)
+	if err := tx.Save(&account).Error; err != nil {
+		return err
+	}
+
+	if m.publisher != nil {
+		if err := m.publisher.PublishBalanceChanged(ctx, tx, idempotencyKey, &account); err != nil {
+			m.logger.WithError(err).Warn("bitcoin: publish balance-changed event failed")
+		}
+	}
+	return nil
+}
+
+// advanceConfirmations recomputes Confirmations for every non-terminal
+// deposit against the node's current height, flips Status pending->confirmed
+// once RequiredConfirms is met, and then confirmed->credited, crediting the
+// destination account atomically with that transition (mirroring
+// internal/chains/ethereum.Monitor.advanceConfirmations).
+func (m *Monitor) advanceConfirmations(ctx context.Context, nodeHeight uint64) error {
+	var deposits []models.CryptoDeposit
+	err := m.db.Where("crypto_type = ? AND network = ? AND status IN ?",
+		models.CryptoTypeBitcoin, m.cfg.NetworkName, []string{models.StatusPending, models.StatusConfirmed}).
+		Find(&deposits).Error
+	if err != nil {
+		return fmt.Errorf("bitcoin: load pending deposits: %w", err)
+	}
+
+	for i := range deposits {
+		d := &deposits[i]
+		if d.BlockHeight == 0 || nodeHeight < d.BlockHeight {
+			continue
+		}
+		confirmations := uint(nodeHeight-d.BlockHeight) + 1
+
+		if confirmations < d.RequiredConfirms {
+			if confirmations != d.Confirmations {
+				if err := m.db.Model(&models.CryptoDeposit{}).Where("id = ?", d.ID).
+					Update("confirmations", confirmations).Error; err != nil {
+					return fmt.Errorf("bitcoin: update deposit %d confirmations: %w", d.ID, err)
+				}
+			}
+			continue
+		}
+
+		addressID := d.AddressID
+		currency := d.Currency
+		if currency == "" {
+			currency = nativeCurrencySymbol
+		}
+		amount := d.Amount
+		idempotencyKey := d.IdempotencyKey
+		txHash := d.TxHash
+		depositID := d.ID
+
+		err := m.db.Transaction(func(gtx *gorm.DB) error {
+			if m.publisher != nil {
+				confirmed := *d
+				confirmed.Confirmations = confirmations
+				confirmed.Status = models.StatusConfirmed
+				if err := m.publisher.PublishConfirmation(ctx, gtx, fmt.Sprintf("%s:confirmed", idempotencyKey), &confirmed); err != nil {
+					m.logger.WithError(err).Warn("bitcoin: publish confirmation event failed")
+				}
+			}
+
+			if err := m.creditAccountBalance(ctx, gtx, addressID, currency, amount, idempotencyKey); err != nil {
+				return fmt.Errorf("bitcoin: credit account for deposit %d: %w", depositID, err)
+			}
+
+			now := time.Now()
+			return gtx.Model(&models.CryptoDeposit{}).Where("id = ?", depositID).
+				Updates(map[string]interface{}{"confirmations": confirmations, "status": models.StatusCredited, "credited_at": now}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("bitcoin: advance deposit %d (tx %s): %w", depositID, txHash, err)
+		}
+	}
+	return nil
+}
+
+// ScanTransactions returns the already-ingested CryptoTransaction rows
+// touching any of the given addresses.
+func (m *Monitor) ScanTransactions(addresses []string) ([]models.CryptoTransaction, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	var txs []models.CryptoTransaction
+	err := m.db.Where("crypto_type = ? AND (to_address IN ? OR from_address IN ?)", models.CryptoTypeBitcoin, addresses, addresses).
+		Order("block_height DESC").
+		Find(&txs).Error
+	if err != nil {
+		return nil, fmt.Errorf("bitcoin: scan transactions: %w", err)
+	}
+	return txs, nil
+}
+
+// watchedAddresses returns a map of active Bitcoin address string -> row ID.
+func (m *Monitor) watchedAddresses() (map[string]uint, error) {
+	var addrs []models.CryptoAddress
+	if err := m.db.Where("crypto_type = ? AND is_active = ?", models.CryptoTypeBitcoin, true).Find(&addrs).Error; err != nil {
+		return nil, fmt.Errorf("bitcoin: load watched addresses: %w", err)
+	}
+	out := make(map[string]uint, len(addrs))
+	for _, a := range addrs {
+		out[strings.TrimSpace(a.Address)] = a.ID
+	}
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/hex"
+
+	zmq4 "github.com/go-zeromq/zmq4"
+)
+
+// StartZMQ subscribes to Bitcoin Core's rawblock/rawtx ZMQ publishers (see
+// -zmqpubrawblock/-zmqpubrawtx) for low-latency notifications. rawtx
+// payloads are decoded just enough to seed the 0-conf mempool cache;
+// rawblock notifications trigger a ScanNewBlocks pass. It blocks until ctx
+// is cancelled, so callers should run it in a goroutine.
+func (m *Monitor) StartZMQ(ctx context.Context) error {
+	if m.cfg.ZMQEndpoint == "" {
+		return nil
+	}
+
+	sock := zmq4.NewSub(ctx)
+	defer sock.Close()
+
+	if err := sock.Dial(m.cfg.ZMQEndpoint); err != nil {
+		return err
+	}
+	if err := sock.SetOption(zmq4.OptionSubscribe, "rawblock"); err != nil {
+		return err
+	}
+	if err := sock.SetOption(zmq4.OptionSubscribe, "rawtx"); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := sock.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			m.logger.WithError(err).Warn("bitcoin: zmq recv failed")
+			continue
+		}
+		if len(msg.Frames) < 2 {
+			continue
+		}
+
+		switch string(msg.Frames[0]) {
+		case "rawtx":
+			m.handleRawTx(msg.Frames[1])
+		case "rawblock":
+			if err := m.ScanNewBlocks(ctx); err != nil {
+				m.logger.WithError(err).Warn("bitcoin: zmq-triggered scan failed")
+			}
+		}
+	}
+}
+
+// handleRawTx decodes just enough of a raw transaction to spot 0-conf
+// payments to watched addresses and park them in the mempool cache; full
+// decoding happens once the tx is confirmed in a block via ProcessBlock.
+func (m *Monitor) handleRawTx(raw []byte) {
+	ctx := context.Background()
+	tx, err := m.rpc.DecodeRawTransaction(ctx, hex.EncodeToString(raw))
+	if err != nil {
+		m.logger.WithError(err).Debug("bitcoin: decode zmq rawtx failed")
+		return
+	}
+
+	watched, err := m.watchedAddresses()
+	if err != nil {
+		return
+	}
+	for _, vout := range tx.Vout {
+		addr := vout.ScriptPubKey.Address
+		if addr == "" && len(vout.ScriptPubKey.Addresses) > 0 {
+			addr = vout.ScriptPubKey.Addresses[0]
+		}
+		if _, ok := watched[addr]; ok {
+			m.mempool.put(mempoolTx{txid: tx.TxID, toAddr: addr, amount: vout.Value, vout: vout.N})
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const wordSize = 32
+
+// keccak256 is a small wrapper kept local so the rest of the package reads
+// as "compute a selector/topic", not "call into go-ethereum's crypto pkg".
+func keccak256(data []byte) []byte {
+	return ethcrypto.Keccak256(data)
+}
+
+// functionSelector returns the first 4 bytes of keccak256(signature), e.g.
+// functionSelector("decimals()") == 0x313ce567.
+func functionSelector(signature string) []byte {
+	return keccak256([]byte(signature))[:4]
+}
+
+// Well-known ERC-20 metadata selectors.
+var (
+	selectorName     = functionSelector("name()")
+	selectorSymbol   = functionSelector("symbol()")
+	selectorDecimals = functionSelector("decimals()")
+)
+
+// decodeTransferLog splits a Transfer(address,address,uint256) log into its
+// from/to/value fields. topics[1] and topics[2] are 32-byte words whose low
+// 20 bytes hold the address; data is a single 32-byte big-endian uint256.
+func decodeTransferLog(topics []common.Hash, data []byte) (from, to common.Address, value *big.Int, err error) {
+	if len(topics) != 3 {
+		return common.Address{}, common.Address{}, nil, fmt.Errorf("ethereum: expected 3 topics for Transfer, got %d", len(topics))
+	}
+	if len(data) != wordSize {
+		return common.Address{}, common.Address{}, nil, fmt.Errorf("ethereum: expected a 32-byte Transfer value word, got %d bytes", len(data))
+	}
+	from = addressFromWord(topics[1].Bytes())
+	to = addressFromWord(topics[2].Bytes())
+	value = new(big.Int).SetBytes(data)
+	return from, to, value, nil
+}
+
+// addressFromWord extracts the low 20 bytes of a left-padded 32-byte word.
+func addressFromWord(word []byte) common.Address {
+	var addr common.Address
+	copy(addr[:], word[len(word)-20:])
+	return addr
+}
+
+// decodeDynamicString decodes a Solidity ABI-encoded `string` return value:
+// a 32-byte offset word (always 0x20 for a single return value), a 32-byte
+// length word, and the right-padded UTF-8 bytes themselves.
+func decodeDynamicString(data []byte) (string, error) {
+	if len(data) < 2*wordSize {
+		return "", fmt.Errorf("ethereum: dynamic string return too short (%d bytes)", len(data))
+	}
+	offset := new(big.Int).SetBytes(data[:wordSize]).Uint64()
+	if uint64(len(data)) < offset+wordSize {
+		return "", fmt.Errorf("ethereum: dynamic string offset %d out of range", offset)
+	}
+	length := new(big.Int).SetBytes(data[offset : offset+wordSize]).Uint64()
+	start := offset + wordSize
+	if uint64(len(data)) < start+length {
+		return "", fmt.Errorf("ethereum: dynamic string length %d out of range", length)
+	}
+	return string(data[start : start+length]), nil
+}
+
+// decodeUint8 pulls a uint8 out of its 32-byte right-aligned ABI word (used
+// for decimals()).
+func decodeUint8(data []byte) (uint8, error) {
+	if len(data) != wordSize {
+		return 0, fmt.Errorf("ethereum: expected a 32-byte uint8 return, got %d bytes", len(data))
+	}
+	return data[wordSize-1], nil
+}
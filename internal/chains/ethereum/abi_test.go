@@ -0,0 +1,66 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func leftPadWord(b []byte) []byte {
+	word := make([]byte, wordSize)
+	copy(word[wordSize-len(b):], b)
+	return word
+}
+
+func TestDecodeTransferLog(t *testing.T) {
+	from := common.HexToAddress("0x000000000000000000000000000000000000aa")
+	to := common.HexToAddress("0x000000000000000000000000000000000000bb")
+	topics := []common.Hash{
+		eventTopic(transferEventSignature),
+		common.BytesToHash(leftPadWord(from.Bytes())),
+		common.BytesToHash(leftPadWord(to.Bytes())),
+	}
+	value := big.NewInt(1_000_000)
+	data := leftPadWord(value.Bytes())
+
+	gotFrom, gotTo, gotValue, err := decodeTransferLog(topics, data)
+	if err != nil {
+		t.Fatalf("decodeTransferLog: %v", err)
+	}
+	if gotFrom != from {
+		t.Errorf("from = %s, want %s", gotFrom, from)
+	}
+	if gotTo != to {
+		t.Errorf("to = %s, want %s", gotTo, to)
+	}
+	if gotValue.Cmp(value) != 0 {
+		t.Errorf("value = %s, want %s", gotValue, value)
+	}
+}
+
+func TestDecodeDynamicString(t *testing.T) {
+	// offset(32) + length(32) + "USDC" right-padded to a 32-byte word.
+	data := append(leftPadWord(big.NewInt(32).Bytes()), leftPadWord(big.NewInt(4).Bytes())...)
+	payload := make([]byte, wordSize)
+	copy(payload, "USDC")
+	data = append(data, payload...)
+
+	got, err := decodeDynamicString(data)
+	if err != nil {
+		t.Fatalf("decodeDynamicString: %v", err)
+	}
+	if got != "USDC" {
+		t.Errorf("got %q, want %q", got, "USDC")
+	}
+}
+
+func TestDecodeUint8(t *testing.T) {
+	got, err := decodeUint8(leftPadWord([]byte{18}))
+	if err != nil {
+		t.Fatalf("decodeUint8: %v", err)
+	}
+	if got != 18 {
+		t.Errorf("got %d, want 18", got)
+	}
+}
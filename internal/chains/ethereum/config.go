@@ -0,0 +1,42 @@
+// Package ethereum implements an ERC-20 + native ETH indexer: it follows
+// new blocks over JSON-RPC/WebSocket, decodes Transfer event logs with a
+// minimal hand-rolled ABI decoder, and keeps a registry of known tokens.
+package ethereum
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Config holds the Ethereum JSON-RPC/WebSocket connection settings.
+type Config struct {
+	RPCURL string
+	WSURL  string
+
+	ChainID          uint64
+	RequiredConfirms uint
+}
+
+// LoadConfig reads ETH_RPC_URL, ETH_WS_URL, and ETH_CHAIN_ID from env.
+func LoadConfig() (Config, error) {
+	rpcURL := os.Getenv("ETH_RPC_URL")
+	if rpcURL == "" {
+		rpcURL = "http://127.0.0.1:8545"
+	}
+	chainIDStr := os.Getenv("ETH_CHAIN_ID")
+	if chainIDStr == "" {
+		chainIDStr = "1"
+	}
+	chainID, err := strconv.ParseUint(chainIDStr, 10, 64)
+	if err != nil {
+		return Config{}, fmt.Errorf("ethereum: invalid ETH_CHAIN_ID %q: %w", chainIDStr, err)
+	}
+
+	return Config{
+		RPCURL:           rpcURL,
+		WSURL:            os.Getenv("ETH_WS_URL"),
+		ChainID:          chainID,
+		RequiredConfirms: 12,
+	}, nil
+}
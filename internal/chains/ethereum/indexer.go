@@ -0,0 +1,418 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// nativeCurrencySymbol is the Account currency credited for plain ETH
+// transfers, mirroring how an ERC-20 transfer credits its token's symbol.
+const nativeCurrencySymbol = "ETH"
+
+// balanceCreditGuardTopic namespaces the EventOutbox rows creditAccountBalance
+// inserts purely as an idempotency guard (distinct from the
+// accounts.balance.v1 topic the configured EventPublisher dispatches on),
+// so a deposit's confirmed->credited transition can never apply its balance
+// delta twice, even if ScanNewBlocks reprocesses the same block after a
+// mid-loop crash or restart.
+const balanceCreditGuardTopic = "ethereum.balance-credit.guard"
+
+// EventPublisher is the minimal hook the monitor calls on deposit/
+// confirmation/balance-change state changes. internal/events provides a
+// Kafka-backed implementation; nil is a valid no-op. tx is the same
+// transaction the state change was just written in, so an outbox-pattern
+// implementation can enqueue atomically with it.
+type EventPublisher interface {
+	PublishDepositSeen(ctx context.Context, tx *gorm.DB, idempotencyKey string, deposit *models.CryptoDeposit) error
+	PublishConfirmation(ctx context.Context, tx *gorm.DB, idempotencyKey string, deposit *models.CryptoDeposit) error
+	PublishBalanceChanged(ctx context.Context, tx *gorm.DB, idempotencyKey string, account *models.Account) error
+}
+
+// Monitor implements models.BlockchainMonitor against an Ethereum node: it
+// scans native ETH transfers from block bodies and ERC-20 Transfer events
+// via eth_getLogs, crediting watched addresses of either kind.
+type Monitor struct {
+	db        *gorm.DB
+	rpc       chainRPC
+	registry  *TokenRegistry
+	cfg       Config
+	logger    *logrus.Logger
+	publisher EventPublisher
+}
+
+// NewMonitor builds an Ethereum Monitor. publisher may be nil.
+func NewMonitor(db *gorm.DB, rpc chainRPC, cfg Config, logger *logrus.Logger, publisher EventPublisher) *Monitor {
+	return &Monitor{
+		db:        db,
+		rpc:       rpc,
+		registry:  NewTokenRegistry(db, rpc, cfg.ChainID),
+		cfg:       cfg,
+		logger:    logger,
+		publisher: publisher,
+	}
+}
+
+// GetLatestBlockHeight returns the node's current block number.
+func (m *Monitor) GetLatestBlockHeight() (uint64, error) {
+	return m.rpc.BlockNumber(context.Background())
+}
+
+// ScanNewBlocks processes every block between our last recorded Ethereum
+// chain tip and the node's current height.
+func (m *Monitor) ScanNewBlocks(ctx context.Context) error {
+	nodeHeight, err := m.rpc.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("ethereum: eth_blockNumber: %w", err)
+	}
+
+	var tip models.ChainTip
+	err = m.db.Where("network = ? AND crypto_type = ?", m.network(), models.CryptoTypeEthereum).
+		Order("height DESC").First(&tip).Error
+	startHeight := uint64(0)
+	if err == nil {
+		startHeight = tip.Height + 1
+	}
+
+	for height := startHeight; height <= nodeHeight; height++ {
+		if err := m.ProcessBlock(height); err != nil {
+			return fmt.Errorf("ethereum: process block %d: %w", height, err)
+		}
+	}
+	return m.advanceConfirmations(ctx, nodeHeight)
+}
+
+// ProcessBlock ingests a single block: native ETH transfers from its
+// transaction bodies, and ERC-20 Transfer events from its logs.
+func (m *Monitor) ProcessBlock(blockHeight uint64) error {
+	ctx := context.Background()
+	block, err := m.rpc.BlockByNumber(ctx, new(big.Int).SetUint64(blockHeight))
+	if err != nil {
+		return fmt.Errorf("ethereum: eth_getBlockByNumber(%d): %w", blockHeight, err)
+	}
+
+	watched, err := m.watchedAddresses()
+	if err != nil {
+		return err
+	}
+
+	blockTime := time.Unix(int64(block.Time()), 0).UTC()
+	if err := m.processNativeTransfers(ctx, block, blockTime, watched); err != nil {
+		return err
+	}
+	if err := m.processTokenTransfers(ctx, block, blockTime, watched); err != nil {
+		return err
+	}
+
+	return m.db.Create(&models.ChainTip{
+		Network:    m.network(),
+		CryptoType: models.CryptoTypeEthereum,
+		Height:     blockHeight,
+		BlockHash:  block.Hash().Hex(),
+		PrevHash:   block.ParentHash().Hex(),
+	}).Error
+}
+
+// processNativeTransfers credits plain ETH transfers (tx.Value() > 0, no
+// contract call) paying a watched address.
+func (m *Monitor) processNativeTransfers(ctx context.Context, block *ethtypes.Block, blockTime time.Time, watched map[string]uint) error {
+	signer := ethtypes.LatestSignerForChainID(new(big.Int).SetUint64(m.cfg.ChainID))
+	for _, tx := range block.Transactions() {
+		if tx.To() == nil || tx.Value().Sign() == 0 {
+			continue
+		}
+		toAddr := strings.ToLower(tx.To().Hex())
+		addressID, ok := watched[toAddr]
+		if !ok {
+			continue
+		}
+		from, err := ethtypes.Sender(signer, tx)
+		if err != nil {
+			m.logger.WithError(err).Warn("ethereum: recover sender failed, skipping tx")
+			continue
+		}
+
+		amount := decimal.NewFromBigInt(tx.Value(), -18).String() // wei -> ETH
+		idempotencyKey := fmt.Sprintf("%s:native", tx.Hash().Hex())
+		if err := m.creditDeposit(ctx, addressID, tx.Hash().Hex(), from.Hex(), tx.To().Hex(), amount, nativeCurrencySymbol, block, blockTime, nil, idempotencyKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processTokenTransfers fetches every Transfer log in this block and
+// credits ERC-20 transfers paying a watched address.
+func (m *Monitor) processTokenTransfers(ctx context.Context, block *ethtypes.Block, blockTime time.Time, watched map[string]uint) error {
+	blockNum := block.NumberU64()
+	logs, err := m.rpc.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(blockNum),
+		ToBlock:   new(big.Int).SetUint64(blockNum),
+		Topics:    [][]common.Hash{{transferTopic0}},
+	})
+	if err != nil {
+		return fmt.Errorf("ethereum: eth_getLogs(%d): %w", blockNum, err)
+	}
+
+	for _, log := range logs {
+		if len(log.Topics) != 3 {
+			continue
+		}
+		from, to, value, err := decodeTransferLog(log.Topics, log.Data)
+		if err != nil {
+			m.logger.WithError(err).Debug("ethereum: skip unparseable Transfer log")
+			continue
+		}
+		toAddr := strings.ToLower(to.Hex())
+		addressID, ok := watched[toAddr]
+		if !ok {
+			continue
+		}
+
+		token, err := m.registry.Lookup(ctx, log.Address)
+		if err != nil {
+			m.logger.WithError(err).Warn("ethereum: token metadata lookup failed, skipping transfer")
+			continue
+		}
+
+		amount := decimal.NewFromBigInt(value, -int32(token.Decimals)).String()
+		idempotencyKey := fmt.Sprintf("%s:%d", log.TxHash.Hex(), log.Index)
+		if err := m.creditDeposit(ctx, addressID, log.TxHash.Hex(), from.Hex(), to.Hex(), amount, token.Symbol, block, blockTime, token, idempotencyKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// creditDeposit records an EthereumTransaction + CryptoDeposit for one
+// incoming transfer, native or ERC-20. It only records that the transfer
+// was seen; the account balance isn't credited until the deposit reaches
+// RequiredConfirms in advanceConfirmations, so currency is stored on the
+// deposit for that later step to use.
+func (m *Monitor) creditDeposit(ctx context.Context, addressID uint, txHash, from, to, amount, currency string, block *ethtypes.Block, blockTime time.Time, token *models.Token, idempotencyKey string) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		transaction := models.CryptoTransaction{
+			AddressID:   addressID,
+			TxHash:      txHash,
+			BlockHash:   block.Hash().Hex(),
+			BlockHeight: block.NumberU64(),
+			BlockTime:   &blockTime,
+			FromAddress: from,
+			ToAddress:   to,
+			Amount:      amount,
+			Status:      models.StatusConfirmed,
+			Network:     m.network(),
+			CryptoType:  models.CryptoTypeEthereum,
+			Direction:   models.DirectionIncoming,
+		}
+		if token != nil {
+			transaction.TokenSymbol = token.Symbol
+			transaction.TokenAmount = amount
+		}
+		// Dedup on tx_hash alone (matching chains/bitcoin's scanner): TxHash
+		// carries a unique constraint, so a tx with multiple watched transfers
+		// (e.g. a token transfer alongside native value, or transfers to two
+		// watched addresses) must still resolve to the single existing row
+		// rather than attempt a second insert and hit the unique violation.
+		if err := tx.Where("tx_hash = ?", txHash).FirstOrCreate(&transaction).Error; err != nil {
+			return fmt.Errorf("ethereum: persist transaction: %w", err)
+		}
+
+		deposit := models.CryptoDeposit{
+			AddressID:        addressID,
+			TxHash:           txHash,
+			IdempotencyKey:   idempotencyKey,
+			FromAddress:      from,
+			Amount:           amount,
+			Currency:         currency,
+			RequiredConfirms: m.cfg.RequiredConfirms,
+			Status:           models.StatusPending,
+			BlockHeight:      block.NumberU64(),
+			BlockTime:        &blockTime,
+			Network:          m.network(),
+			CryptoType:       models.CryptoTypeEthereum,
+		}
+		if err := tx.Where("idempotency_key = ?", idempotencyKey).FirstOrCreate(&deposit).Error; err != nil {
+			return fmt.Errorf("ethereum: persist deposit: %w", err)
+		}
+
+		if m.publisher != nil {
+			if err := m.publisher.PublishDepositSeen(ctx, tx, idempotencyKey, &deposit); err != nil {
+				m.logger.WithError(err).Warn("ethereum: publish deposit-seen event failed")
+			}
+		}
+		return nil
+	})
+}
+
+// creditAccountBalance updates the user's spot Account for currency (an
+// ERC-20 token symbol or nativeCurrencySymbol) within tx, the caller's own
+// transaction for the deposit's confirmed->credited transition. Before
+// touching the account it inserts a balanceCreditGuardTopic EventOutbox row
+// keyed on idempotencyKey; a conflict there means this deposit was already
+// credited (e.g. a retry after a mid-loop crash), so the balance update is
+// skipped rather than applied twice.
+func (m *Monitor) creditAccountBalance(ctx context.Context, tx *gorm.DB, addressID uint, currency, amount, idempotencyKey string) error {
+	guard := models.EventOutbox{
+		Topic:          balanceCreditGuardTopic,
+		IdempotencyKey: idempotencyKey,
+		EventType:      "ethereum.balance.credited",
+		Payload:        "{}",
+		Status:         models.EventOutboxStatusSent,
+	}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&guard)
+	if result.Error != nil {
+		return fmt.Errorf("ethereum: record balance-credit guard: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	var address models.CryptoAddress
+	if err := tx.First(&address, addressID).Error; err != nil {
+		return fmt.Errorf("ethereum: load address %d: %w", addressID, err)
+	}
+	delta, err := decimal.NewFromString(amount)
+	if err != nil {
+		return fmt.Errorf("ethereum: parse credit amount %q: %w", amount, err)
+	}
+
+	var account models.Account
+	err = tx.Where("user_id = ? AND currency = ? AND type = ?",
+		fmt.Sprint(address.UserID), currency, models.AccountTypeSpot).First(&account).Error
+	if err != nil {
+		account = models.Account{
+			UserID:   fmt.Sprint(address.UserID),
+			Type:     models.AccountTypeSpot,
+			Currency: currency,
+		}
+		if err := tx.Create(&account).Error; err != nil {
+			return fmt.Errorf("ethereum: create account: %w", err)
+		}
+	}
+	account.Balance = account.Balance.Add(delta)
+	account.AvailableBalance = account.AvailableBalance.Add(delta)
+	if err := tx.Save(&account).Error; err != nil {
+		return err
+	}
+
+	if m.publisher != nil {
+		if err := m.publisher.PublishBalanceChanged(ctx, tx, idempotencyKey, &account); err != nil {
+			m.logger.WithError(err).Warn("ethereum: publish balance-changed event failed")
+		}
+	}
+	return nil
+}
+
+// advanceConfirmations recomputes Confirmations for every non-terminal
+// Ethereum deposit against the node's current height. Once a deposit
+// crosses RequiredConfirms for the first time, it is atomically flipped
+// pending->confirmed->credited and its account balance credited exactly
+// once, guarded by the idempotency-key row creditAccountBalance inserts in
+// the same transaction - so re-running this scan after a crash or restart
+// can never double-credit an account.
+func (m *Monitor) advanceConfirmations(ctx context.Context, nodeHeight uint64) error {
+	var deposits []models.CryptoDeposit
+	err := m.db.Where("crypto_type = ? AND network = ? AND status IN ?",
+		models.CryptoTypeEthereum, m.network(), []string{models.StatusPending, models.StatusConfirmed}).
+		Find(&deposits).Error
+	if err != nil {
+		return fmt.Errorf("ethereum: load pending deposits: %w", err)
+	}
+
+	for i := range deposits {
+		d := &deposits[i]
+		if d.BlockHeight == 0 || nodeHeight < d.BlockHeight {
+			continue
+		}
+		confirmations := uint(nodeHeight-d.BlockHeight) + 1
+
+		if confirmations < d.RequiredConfirms {
+			if confirmations != d.Confirmations {
+				if err := m.db.Model(&models.CryptoDeposit{}).Where("id = ?", d.ID).
+					Update("confirmations", confirmations).Error; err != nil {
+					return fmt.Errorf("ethereum: update deposit %d confirmations: %w", d.ID, err)
+				}
+			}
+			continue
+		}
+
+		addressID := d.AddressID
+		currency := d.Currency
+		amount := d.Amount
+		idempotencyKey := d.IdempotencyKey
+		txHash := d.TxHash
+		depositID := d.ID
+
+		err := m.db.Transaction(func(gtx *gorm.DB) error {
+			if m.publisher != nil {
+				confirmed := *d
+				confirmed.Confirmations = confirmations
+				confirmed.Status = models.StatusConfirmed
+				if err := m.publisher.PublishConfirmation(ctx, gtx, fmt.Sprintf("%s:confirmed", idempotencyKey), &confirmed); err != nil {
+					m.logger.WithError(err).Warn("ethereum: publish confirmation event failed")
+				}
+			}
+
+			if err := m.creditAccountBalance(ctx, gtx, addressID, currency, amount, idempotencyKey); err != nil {
+				return fmt.Errorf("ethereum: credit account for deposit %d: %w", depositID, err)
+			}
+
+			now := time.Now()
+			return gtx.Model(&models.CryptoDeposit{}).Where("id = ?", depositID).
+				Updates(map[string]interface{}{"confirmations": confirmations, "status": models.StatusCredited, "credited_at": now}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("ethereum: advance deposit %d (tx %s): %w", depositID, txHash, err)
+		}
+	}
+	return nil
+}
+
+// watchedAddresses returns a lowercased Ethereum address -> row ID map of
+// every active Ethereum CryptoAddress.
+func (m *Monitor) watchedAddresses() (map[string]uint, error) {
+	var addrs []models.CryptoAddress
+	if err := m.db.Where("crypto_type = ? AND is_active = ?", models.CryptoTypeEthereum, true).Find(&addrs).Error; err != nil {
+		return nil, fmt.Errorf("ethereum: load watched addresses: %w", err)
+	}
+	out := make(map[string]uint, len(addrs))
+	for _, a := range addrs {
+		out[strings.ToLower(a.Address)] = a.ID
+	}
+	return out, nil
+}
+
+// ScanTransactions returns already-ingested CryptoTransaction rows touching
+// any of the given addresses.
+func (m *Monitor) ScanTransactions(addresses []string) ([]models.CryptoTransaction, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	var txs []models.CryptoTransaction
+	err := m.db.Where("crypto_type = ? AND (to_address IN ? OR from_address IN ?)", models.CryptoTypeEthereum, addresses, addresses).
+		Order("block_height DESC").
+		Find(&txs).Error
+	if err != nil {
+		return nil, fmt.Errorf("ethereum: scan transactions: %w", err)
+	}
+	return txs, nil
+}
+
+func (m *Monitor) network() string {
+	if m.cfg.ChainID == 1 {
+		return models.NetworkMainnet
+	}
+	return models.NetworkSepolia
+}
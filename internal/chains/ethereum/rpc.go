@@ -0,0 +1,47 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// chainRPC is the subset of an Ethereum JSON-RPC client the indexer and
+// token registry depend on; it exists so tests can substitute a mock node.
+type chainRPC interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// RPCClient wraps go-ethereum's ethclient with the narrower chainRPC
+// surface this package needs.
+type RPCClient struct {
+	*ethclient.Client
+}
+
+// NewRPCClient dials the configured Ethereum JSON-RPC endpoint.
+func NewRPCClient(cfg Config) (*RPCClient, error) {
+	client, err := ethclient.Dial(cfg.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("ethereum: dial %s: %w", cfg.RPCURL, err)
+	}
+	return &RPCClient{Client: client}, nil
+}
+
+// transferEventSignature is "Transfer(address,address,uint256)"; its
+// keccak256 hash is topic0 for every ERC-20 Transfer log.
+const transferEventSignature = "Transfer(address,address,uint256)"
+
+var transferTopic0 = eventTopic(transferEventSignature)
+
+// eventTopic computes the topic0 hash for an event signature string.
+func eventTopic(signature string) common.Hash {
+	return common.BytesToHash(keccak256([]byte(signature)))
+}
@@ -0,0 +1,98 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// TokenRegistry resolves ERC-20 contract addresses to their on-chain
+// metadata, auto-populating new tokens the first time they're seen.
+type TokenRegistry struct {
+	db      *gorm.DB
+	rpc     chainRPC
+	chainID uint64
+}
+
+// NewTokenRegistry builds a registry backed by db and an RPC client.
+func NewTokenRegistry(db *gorm.DB, rpc chainRPC, chainID uint64) *TokenRegistry {
+	return &TokenRegistry{db: db, rpc: rpc, chainID: chainID}
+}
+
+// Lookup returns the known Token for a contract address, auto-populating it
+// via on-chain name()/symbol()/decimals() calls if it hasn't been seen yet.
+func (r *TokenRegistry) Lookup(ctx context.Context, contractAddress common.Address) (*models.Token, error) {
+	addr := contractAddress.Hex()
+
+	var token models.Token
+	err := r.db.Where("contract_address = ? AND chain_id = ?", addr, r.chainID).First(&token).Error
+	if err == nil {
+		return &token, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("ethereum: load token %s: %w", addr, err)
+	}
+
+	name, symbol, decimals, err := r.fetchMetadata(ctx, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("ethereum: fetch metadata for %s: %w", addr, err)
+	}
+	token = models.Token{
+		ContractAddress: addr,
+		ChainID:         r.chainID,
+		Symbol:          symbol,
+		Name:            name,
+		Decimals:        decimals,
+		IsActive:        true,
+	}
+	if err := r.db.Where("contract_address = ? AND chain_id = ?", addr, r.chainID).
+		Assign(token).FirstOrCreate(&token).Error; err != nil {
+		return nil, fmt.Errorf("ethereum: persist token %s: %w", addr, err)
+	}
+	return &token, nil
+}
+
+// fetchMetadata ABI-calls name()/symbol()/decimals() on a contract and
+// decodes their return values per the Solidity ABI.
+func (r *TokenRegistry) fetchMetadata(ctx context.Context, contractAddress common.Address) (name, symbol string, decimals uint8, err error) {
+	nameData, err := r.call(ctx, contractAddress, selectorName)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("name(): %w", err)
+	}
+	name, err = decodeDynamicString(nameData)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("decode name(): %w", err)
+	}
+
+	symbolData, err := r.call(ctx, contractAddress, selectorSymbol)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("symbol(): %w", err)
+	}
+	symbol, err = decodeDynamicString(symbolData)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("decode symbol(): %w", err)
+	}
+
+	decimalsData, err := r.call(ctx, contractAddress, selectorDecimals)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("decimals(): %w", err)
+	}
+	decimals, err = decodeUint8(decimalsData)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("decode decimals(): %w", err)
+	}
+
+	return name, symbol, decimals, nil
+}
+
+func (r *TokenRegistry) call(ctx context.Context, contractAddress common.Address, selector []byte) ([]byte, error) {
+	return r.rpc.CallContract(ctx, ethereum.CallMsg{
+		To:   &contractAddress,
+		Data: selector,
+	}, nil)
+}
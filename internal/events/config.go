@@ -0,0 +1,35 @@
+package events
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// Config holds the dispatcher's Kafka connection and polling settings.
+type Config struct {
+	Brokers     []string
+	Compression kafka.Compression
+
+	DispatchInterval  time.Duration
+	DispatchBatchSize int
+}
+
+// DefaultConfig builds a Config from brokers (as already parsed by the
+// caller, e.g. api.Server's KAFKA_BROKERS) plus EVENTS_COMPRESSION
+// ("gzip", the default, or "snappy") from the environment.
+func DefaultConfig(brokers []string) Config {
+	compression := kafka.Gzip
+	if strings.EqualFold(os.Getenv("EVENTS_COMPRESSION"), "snappy") {
+		compression = kafka.Snappy
+	}
+
+	return Config{
+		Brokers:           brokers,
+		Compression:       compression,
+		DispatchInterval:  2 * time.Second,
+		DispatchBatchSize: 100,
+	}
+}
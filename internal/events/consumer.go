@@ -0,0 +1,47 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// Handler processes one decoded Event; returning an error leaves the
+// message uncommitted so it is redelivered to this consumer group.
+type Handler func(ctx context.Context, event Event) error
+
+// NewConsumer builds a kafka-go Reader subscribed to topic under groupID,
+// so multiple instances of the same downstream service (ledger,
+// notifications) can share the partition load.
+func NewConsumer(brokers []string, topic, groupID string) *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		Topic:   topic,
+		GroupID: groupID,
+	})
+}
+
+// Consume reads messages from reader until ctx is canceled or handler
+// returns an error, decoding each message as an Event before invoking
+// handler and only then committing its offset.
+func Consume(ctx context.Context, reader *kafka.Reader, handler Handler) error {
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			return fmt.Errorf("events: fetch message: %w", err)
+		}
+
+		var event Event
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			return fmt.Errorf("events: decode event: %w", err)
+		}
+		if err := handler(ctx, event); err != nil {
+			return fmt.Errorf("events: handle event %s: %w", event.ID, err)
+		}
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			return fmt.Errorf("events: commit offset: %w", err)
+		}
+	}
+}
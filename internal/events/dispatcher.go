@@ -0,0 +1,97 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// maxDispatchAttempts caps how many times the dispatcher retries a row
+// before giving up and marking it failed; it stays visible for manual
+// replay via /events/replay rather than being dropped.
+const maxDispatchAttempts = 10
+
+// Dispatcher polls EventOutbox for pending rows and publishes them to
+// Kafka, marking each sent (or, past maxDispatchAttempts, failed).
+type Dispatcher struct {
+	db        *gorm.DB
+	publisher *Publisher
+	logger    *logrus.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+// NewDispatcher builds a Dispatcher from cfg.
+func NewDispatcher(db *gorm.DB, cfg Config, logger *logrus.Logger) *Dispatcher {
+	return &Dispatcher{
+		db:        db,
+		publisher: NewPublisher(cfg.Brokers, cfg.Compression),
+		logger:    logger,
+		interval:  cfg.DispatchInterval,
+		batchSize: cfg.DispatchBatchSize,
+	}
+}
+
+// Run polls the outbox on Dispatcher's interval until ctx is canceled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return d.publisher.Close()
+		case <-ticker.C:
+			if err := d.dispatchPending(ctx); err != nil {
+				d.logger.WithError(err).Warn("events: dispatch pending outbox rows failed")
+			}
+		}
+	}
+}
+
+// dispatchPending publishes up to batchSize pending rows, oldest first.
+func (d *Dispatcher) dispatchPending(ctx context.Context) error {
+	var rows []models.EventOutbox
+	err := d.db.Where("status = ?", models.EventOutboxStatusPending).
+		Order("id ASC").Limit(d.batchSize).Find(&rows).Error
+	if err != nil {
+		return fmt.Errorf("events: load pending outbox rows: %w", err)
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if err := d.dispatchOne(ctx, row); err != nil {
+			d.logger.WithError(err).WithField("outbox_id", row.ID).Warn("events: publish outbox row failed")
+		}
+	}
+	return nil
+}
+
+// dispatchOne publishes a single row and records the outcome.
+func (d *Dispatcher) dispatchOne(ctx context.Context, row *models.EventOutbox) error {
+	pubErr := d.publisher.Publish(ctx, row.Topic, row.IdempotencyKey, []byte(row.Payload))
+	attempts := row.Attempts + 1
+
+	if pubErr != nil {
+		status := models.EventOutboxStatusPending
+		if attempts >= maxDispatchAttempts {
+			status = models.EventOutboxStatusFailed
+		}
+		return d.db.Model(row).Updates(map[string]interface{}{
+			"status":     status,
+			"attempts":   attempts,
+			"last_error": pubErr.Error(),
+		}).Error
+	}
+
+	now := time.Now()
+	return d.db.Model(row).Updates(map[string]interface{}{
+		"status":   models.EventOutboxStatusSent,
+		"attempts": attempts,
+		"sent_at":  &now,
+	}).Error
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// DomainPublisher enqueues outbox rows for deposit, confirmation,
+// withdrawal, and balance-change events. It satisfies the narrow
+// EventPublisher interfaces declared by internal/chains/bitcoin,
+// internal/chains/ethereum, and internal/withdraw/bitcoin.
+type DomainPublisher struct{}
+
+// NewDomainPublisher builds a DomainPublisher.
+func NewDomainPublisher() *DomainPublisher {
+	return &DomainPublisher{}
+}
+
+// PublishDepositSeen implements chains/bitcoin.EventPublisher and
+// chains/ethereum.EventPublisher.
+func (p *DomainPublisher) PublishDepositSeen(ctx context.Context, tx *gorm.DB, idempotencyKey string, deposit *models.CryptoDeposit) error {
+	return Enqueue(tx, TopicDeposits, idempotencyKey, "crypto.deposit.seen", deposit)
+}
+
+// PublishConfirmation implements chains/bitcoin.EventPublisher and
+// chains/ethereum.EventPublisher.
+func (p *DomainPublisher) PublishConfirmation(ctx context.Context, tx *gorm.DB, idempotencyKey string, deposit *models.CryptoDeposit) error {
+	return Enqueue(tx, TopicConfirmations, idempotencyKey, "crypto.deposit.confirmed", deposit)
+}
+
+// PublishBalanceChanged implements chains/bitcoin.EventPublisher and
+// chains/ethereum.EventPublisher.
+func (p *DomainPublisher) PublishBalanceChanged(ctx context.Context, tx *gorm.DB, idempotencyKey string, account *models.Account) error {
+	return Enqueue(tx, TopicAccountBalance, idempotencyKey, "accounts.balance.changed", account)
+}
+
+// PublishWithdrawalCreated implements withdraw/bitcoin.EventPublisher.
+func (p *DomainPublisher) PublishWithdrawalCreated(ctx context.Context, tx *gorm.DB, idempotencyKey string, withdrawal *models.CryptoWithdrawal) error {
+	return Enqueue(tx, TopicWithdrawals, idempotencyKey, "crypto.withdrawal.created", withdrawal)
+}
+
+// PublishWithdrawalBroadcast implements withdraw/bitcoin.EventPublisher.
+func (p *DomainPublisher) PublishWithdrawalBroadcast(ctx context.Context, tx *gorm.DB, idempotencyKey string, withdrawal *models.CryptoWithdrawal) error {
+	return Enqueue(tx, TopicWithdrawals, idempotencyKey, "crypto.withdrawal.broadcast", withdrawal)
+}
+
+// PublishWithdrawalConfirmed implements withdraw/bitcoin.EventPublisher.
+func (p *DomainPublisher) PublishWithdrawalConfirmed(ctx context.Context, tx *gorm.DB, idempotencyKey string, withdrawal *models.CryptoWithdrawal) error {
+	return Enqueue(tx, TopicWithdrawals, idempotencyKey, "crypto.withdrawal.confirmed", withdrawal)
+}
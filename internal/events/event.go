@@ -0,0 +1,41 @@
+// Package events is a Kafka-backed domain event bus: an outbox pattern for
+// at-least-once publication of deposit/withdrawal/confirmation/balance
+// events, plus a consumer helper for downstream services (ledger,
+// notifications) to subscribe with consumer groups.
+package events
+
+import "time"
+
+// Kafka topics this bus publishes to.
+const (
+	TopicDeposits       = "crypto.deposits.v1"
+	TopicWithdrawals    = "crypto.withdrawals.v1"
+	TopicConfirmations  = "crypto.confirmations.v1"
+	TopicAccountBalance = "accounts.balance.v1"
+)
+
+const eventSource = "crypto_trading_v2"
+
+// Event is a CloudEvents-style envelope (see cloudevents.io/spec) wrapping
+// a domain payload; ID doubles as the idempotency/dedup key consumers
+// should key off of.
+type Event struct {
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Time            time.Time   `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+// newEvent builds an Event envelope around data, keyed by idempotencyKey.
+func newEvent(eventType, idempotencyKey string, data interface{}) Event {
+	return Event{
+		ID:              idempotencyKey,
+		Source:          eventSource,
+		Type:            eventType,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
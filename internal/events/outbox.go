@@ -0,0 +1,34 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Enqueue writes a pending EventOutbox row using tx, the caller's own
+// transaction, so the event is only ever recorded if the domain state
+// change it describes also commits. A duplicate (topic, idempotencyKey)
+// pair is silently ignored, so retrying the same state change is safe.
+func Enqueue(tx *gorm.DB, topic, idempotencyKey, eventType string, data interface{}) error {
+	event := newEvent(eventType, idempotencyKey, data)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("events: marshal event payload: %w", err)
+	}
+
+	row := models.EventOutbox{
+		Topic:          topic,
+		IdempotencyKey: idempotencyKey,
+		EventType:      eventType,
+		Payload:        string(payload),
+		Status:         models.EventOutboxStatusPending,
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+		return fmt.Errorf("events: enqueue outbox row: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// Publisher lazily creates one kafka-go Writer per topic.
+type Publisher struct {
+	brokers     []string
+	compression kafka.Compression
+
+	mu      sync.Mutex
+	writers map[string]*kafka.Writer
+}
+
+// NewPublisher builds a Publisher against brokers, compressing every
+// message with compression.
+func NewPublisher(brokers []string, compression kafka.Compression) *Publisher {
+	return &Publisher{
+		brokers:     brokers,
+		compression: compression,
+		writers:     make(map[string]*kafka.Writer),
+	}
+}
+
+func (p *Publisher) writerFor(topic string) *kafka.Writer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if w, ok := p.writers[topic]; ok {
+		return w
+	}
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(p.brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.LeastBytes{},
+		Compression:  p.compression,
+		RequiredAcks: kafka.RequireAll,
+	}
+	p.writers[topic] = w
+	return w
+}
+
+// Publish sends one outbox row's payload to topic, keyed by key so
+// retries and reorderings of the same logical event land on one
+// partition.
+func (p *Publisher) Publish(ctx context.Context, topic, key string, payload []byte) error {
+	w := p.writerFor(topic)
+	if err := w.WriteMessages(ctx, kafka.Message{Key: []byte(key), Value: payload}); err != nil {
+		return fmt.Errorf("events: publish to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// Close flushes and closes every writer this Publisher has opened.
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for topic, w := range p.writers {
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("events: close writer for %s: %w", topic, err)
+		}
+	}
+	return nil
+}
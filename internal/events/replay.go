@@ -0,0 +1,21 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// ReplayRange resets every outbox row with ID in [fromID, toID] back to
+// pending so the dispatcher re-publishes it. Used for disaster recovery
+// when a downstream consumer needs a range of events resent.
+func ReplayRange(db *gorm.DB, fromID, toID uint) (int64, error) {
+	result := db.Model(&models.EventOutbox{}).
+		Where("id BETWEEN ? AND ?", fromID, toID).
+		Updates(map[string]interface{}{"status": models.EventOutboxStatusPending, "last_error": ""})
+	if result.Error != nil {
+		return 0, fmt.Errorf("events: replay range [%d,%d]: %w", fromID, toID, result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -87,6 +87,7 @@ const (
 	StatusConfirmed   = "confirmed"
 	StatusFailed      = "failed"
 	StatusCredited    = "credited"
+	StatusOrphaned    = "orphaned"
 )
 
 // Constants for transaction direction
@@ -96,10 +97,17 @@ const (
 	DirectionInternal = "internal"
 )
 
+// Constants for EventOutbox dispatch status
+const (
+	EventOutboxStatusPending = "pending"
+	EventOutboxStatusSent    = "sent"
+	EventOutboxStatusFailed  = "failed"
+)
+
 // Constants for Bitcoin address types
 const (
-	AddressTypeP2PKH = "P2PKH" // Pay to Public Key Hash (legacy)
-	AddressTypeP2SH  = "P2SH"  // Pay to Script Hash
+	AddressTypeP2PKH  = "P2PKH"  // Pay to Public Key Hash (legacy)
+	AddressTypeP2SH   = "P2SH"   // Pay to Script Hash
 	AddressTypeP2WPKH = "P2WPKH" // Pay to Witness Public Key Hash (native SegWit)
 	AddressTypeP2WSH  = "P2WSH"  // Pay to Witness Script Hash
 	AddressTypeP2TR   = "P2TR"   // Pay to Taproot (Taproot)
@@ -118,12 +126,12 @@ func (e CryptoError) Error() string {
 
 // Common error codes
 const (
-	ErrInvalidAddress     = "INVALID_ADDRESS"
-	ErrInvalidAmount      = "INVALID_AMOUNT"
-	ErrInsufficientFunds  = "INSUFFICIENT_FUNDS"
-	ErrTransactionFailed  = "TRANSACTION_FAILED"
-	ErrNetworkError       = "NETWORK_ERROR"
-	ErrInvalidPrivateKey  = "INVALID_PRIVATE_KEY"
-	ErrWalletNotFound     = "WALLET_NOT_FOUND"
+	ErrInvalidAddress       = "INVALID_ADDRESS"
+	ErrInvalidAmount        = "INVALID_AMOUNT"
+	ErrInsufficientFunds    = "INSUFFICIENT_FUNDS"
+	ErrTransactionFailed    = "TRANSACTION_FAILED"
+	ErrNetworkError         = "NETWORK_ERROR"
+	ErrInvalidPrivateKey    = "INVALID_PRIVATE_KEY"
+	ErrWalletNotFound       = "WALLET_NOT_FOUND"
 	ErrDuplicateTransaction = "DUPLICATE_TRANSACTION"
-)
\ No newline at end of file
+)
@@ -6,20 +6,33 @@ import (
 	"gorm.io/gorm"
 )
 
+// HDSeed stores a single encrypted BIP32 master seed per user. Signing keys
+// are derived from it just-in-time and are never persisted in cleartext.
+type HDSeed struct {
+	gorm.Model
+	UserID         uint   `json:"user_id" gorm:"not null;uniqueIndex"`
+	Ciphertext     []byte `json:"-" gorm:"column:ciphertext;not null"` // AES-GCM(seed), key from KDF(master key)
+	Nonce          []byte `json:"-" gorm:"column:nonce;not null"`
+	KDFSalt        []byte `json:"-" gorm:"column:kdf_salt;not null"`
+	WordCount      uint   `json:"word_count" gorm:"not null"` // 12 (128-bit) or 24 (256-bit) words
+	NextBitcoinIx  uint32 `json:"-" gorm:"column:next_bitcoin_index;default:0"`
+	NextEthereumIx uint32 `json:"-" gorm:"column:next_ethereum_index;default:0"`
+}
+
 // Base CryptoAddress model
 type CryptoAddress struct {
 	gorm.Model
-	UserID      uint   `json:"user_id" gorm:"not null;index"`
-	Address     string `json:"address" gorm:"unique;not null"`
-	PublicKey   string `json:"public_key,omitempty"`
-	PrivateKey  string `json:"-" gorm:"column:private_key"` // Never serialize to JSON
-	Network     string `json:"network" gorm:"not null"`     // mainnet, testnet, regtest
-	CryptoType  string `json:"crypto_type" gorm:"not null"` // bitcoin, ethereum, etc.
-	Label       string `json:"label,omitempty"`
-	IsActive    bool   `json:"is_active" gorm:"default:true"`
-	Balance     string `json:"balance" gorm:"type:decimal(28,18);default:0"` // Use string for precision
-	LastSyncAt  *time.Time `json:"last_sync_at,omitempty"`
-	
+	UserID              uint       `json:"user_id" gorm:"not null;index"`
+	Address             string     `json:"address" gorm:"unique;not null"`
+	PublicKey           string     `json:"public_key,omitempty"`
+	EncryptedPrivateKey []byte     `json:"-" gorm:"column:encrypted_private_key"` // nullable; only set for imported, non-HD keys
+	Network             string     `json:"network" gorm:"not null"`               // mainnet, testnet, regtest
+	CryptoType          string     `json:"crypto_type" gorm:"not null"`           // bitcoin, ethereum, etc.
+	Label               string     `json:"label,omitempty"`
+	IsActive            bool       `json:"is_active" gorm:"default:true"`
+	Balance             string     `json:"balance" gorm:"type:decimal(28,18);default:0"` // Use string for precision
+	LastSyncAt          *time.Time `json:"last_sync_at,omitempty"`
+
 	// Relationships
 	User         User                `json:"user,omitempty" gorm:"foreignKey:UserID"`
 	Deposits     []CryptoDeposit     `json:"deposits,omitempty" gorm:"foreignKey:AddressID"`
@@ -47,28 +60,29 @@ type EthereumAddress struct {
 	IsContract   bool   `json:"is_contract" gorm:"default:false"`
 	ContractABI  string `json:"contract_abi,omitempty" gorm:"type:text"`
 	TokenBalance string `json:"token_balance,omitempty" gorm:"type:decimal(28,18);default:0"`
+	Derivation   string `json:"derivation,omitempty"` // HD wallet derivation path
 }
 
 // Base CryptoTransaction model
 type CryptoTransaction struct {
 	gorm.Model
-	AddressID        uint      `json:"address_id" gorm:"not null;index"`
-	TxHash           string    `json:"tx_hash" gorm:"unique;not null"`
-	BlockHash        string    `json:"block_hash,omitempty"`
-	BlockHeight      uint64    `json:"block_height,omitempty"`
-	BlockTime        *time.Time `json:"block_time,omitempty"`
-	FromAddress      string    `json:"from_address"`
-	ToAddress        string    `json:"to_address"`
-	Amount           string    `json:"amount" gorm:"type:decimal(28,18);not null"`
-	Fee              string    `json:"fee" gorm:"type:decimal(28,18);default:0"`
-	Status           string    `json:"status" gorm:"default:pending"` // pending, confirmed, failed
-	Confirmations    uint      `json:"confirmations" gorm:"default:0"`
-	Network          string    `json:"network" gorm:"not null"`
-	CryptoType       string    `json:"crypto_type" gorm:"not null"`
-	Direction        string    `json:"direction" gorm:"not null"` // incoming, outgoing, internal
-	RawTransaction   string    `json:"raw_transaction,omitempty" gorm:"type:text"`
-	Memo             string    `json:"memo,omitempty"`
-	
+	AddressID      uint       `json:"address_id" gorm:"not null;index"`
+	TxHash         string     `json:"tx_hash" gorm:"unique;not null"`
+	BlockHash      string     `json:"block_hash,omitempty"`
+	BlockHeight    uint64     `json:"block_height,omitempty"`
+	BlockTime      *time.Time `json:"block_time,omitempty"`
+	FromAddress    string     `json:"from_address"`
+	ToAddress      string     `json:"to_address"`
+	Amount         string     `json:"amount" gorm:"type:decimal(28,18);not null"`
+	Fee            string     `json:"fee" gorm:"type:decimal(28,18);default:0"`
+	Status         string     `json:"status" gorm:"default:pending"` // pending, confirmed, failed
+	Confirmations  uint       `json:"confirmations" gorm:"default:0"`
+	Network        string     `json:"network" gorm:"not null"`
+	CryptoType     string     `json:"crypto_type" gorm:"not null"`
+	Direction      string     `json:"direction" gorm:"not null"` // incoming, outgoing, internal
+	RawTransaction string     `json:"raw_transaction,omitempty" gorm:"type:text"`
+	Memo           string     `json:"memo,omitempty"`
+
 	// Relationships
 	Address CryptoAddress `json:"address,omitempty" gorm:"foreignKey:AddressID"`
 }
@@ -76,19 +90,26 @@ type CryptoTransaction struct {
 // Base CryptoDeposit model
 type CryptoDeposit struct {
 	gorm.Model
-	AddressID       uint      `json:"address_id" gorm:"not null;index"`
-	TxHash          string    `json:"tx_hash" gorm:"unique;not null"`
-	FromAddress     string    `json:"from_address" gorm:"not null"`
-	Amount          string    `json:"amount" gorm:"type:decimal(28,18);not null"`
-	Confirmations   uint      `json:"confirmations" gorm:"default:0"`
-	RequiredConfirms uint     `json:"required_confirms" gorm:"default:6"`
-	Status          string    `json:"status" gorm:"default:pending"` // pending, confirmed, credited
-	BlockHeight     uint64    `json:"block_height,omitempty"`
-	BlockTime       *time.Time `json:"block_time,omitempty"`
-	CreditedAt      *time.Time `json:"credited_at,omitempty"`
-	Network         string    `json:"network" gorm:"not null"`
-	CryptoType      string    `json:"crypto_type" gorm:"not null"`
-	
+	AddressID uint   `json:"address_id" gorm:"not null;index"`
+	TxHash    string `json:"tx_hash" gorm:"index;not null"`
+	// IdempotencyKey dedups one on-chain event into exactly one deposit row:
+	// "tx_hash:vout" for Bitcoin, "tx_hash:log_index" for an ERC-20 transfer,
+	// "tx_hash:native" for a plain ETH transfer. TxHash alone isn't unique
+	// across deposits since a single tx can pay multiple watched addresses
+	// or emit multiple Transfer logs.
+	IdempotencyKey   string     `json:"idempotency_key" gorm:"uniqueIndex;not null"`
+	FromAddress      string     `json:"from_address" gorm:"not null"`
+	Amount           string     `json:"amount" gorm:"type:decimal(28,18);not null"`
+	Currency         string     `json:"currency,omitempty"` // account currency this deposit credits: native symbol or ERC-20 token symbol
+	Confirmations    uint       `json:"confirmations" gorm:"default:0"`
+	RequiredConfirms uint       `json:"required_confirms" gorm:"default:6"`
+	Status           string     `json:"status" gorm:"default:pending"` // pending, confirmed, credited
+	BlockHeight      uint64     `json:"block_height,omitempty"`
+	BlockTime        *time.Time `json:"block_time,omitempty"`
+	CreditedAt       *time.Time `json:"credited_at,omitempty"`
+	Network          string     `json:"network" gorm:"not null"`
+	CryptoType       string     `json:"crypto_type" gorm:"not null"`
+
 	// Relationships
 	Address CryptoAddress `json:"address,omitempty" gorm:"foreignKey:AddressID"`
 }
@@ -96,21 +117,22 @@ type CryptoDeposit struct {
 // Base CryptoWithdrawal model
 type CryptoWithdrawal struct {
 	gorm.Model
-	FromAddressID   uint      `json:"from_address_id" gorm:"not null;index"`
-	ToAddress       string    `json:"to_address" gorm:"not null"`
-	Amount          string    `json:"amount" gorm:"type:decimal(28,18);not null"`
-	Fee             string    `json:"fee" gorm:"type:decimal(28,18);not null"`
-	TxHash          string    `json:"tx_hash,omitempty"`
-	Status          string    `json:"status" gorm:"default:pending"` // pending, broadcasting, confirmed, failed
-	FailureReason   string    `json:"failure_reason,omitempty"`
-	BlockHeight     uint64    `json:"block_height,omitempty"`
-	Confirmations   uint      `json:"confirmations" gorm:"default:0"`
-	BroadcastAt     *time.Time `json:"broadcast_at,omitempty"`
-	ConfirmedAt     *time.Time `json:"confirmed_at,omitempty"`
-	Network         string    `json:"network" gorm:"not null"`
-	CryptoType      string    `json:"crypto_type" gorm:"not null"`
-	Memo            string    `json:"memo,omitempty"`
-	
+	FromAddressID uint       `json:"from_address_id" gorm:"not null;index"`
+	ToAddress     string     `json:"to_address" gorm:"not null"`
+	Amount        string     `json:"amount" gorm:"type:decimal(28,18);not null"`
+	Fee           string     `json:"fee" gorm:"type:decimal(28,18);not null"`
+	TxHash        string     `json:"tx_hash,omitempty"`
+	SignedRawTx   string     `json:"-" gorm:"type:text"`            // fully signed raw tx hex, held between ProcessWithdrawal and broadcast; never the txid
+	Status        string     `json:"status" gorm:"default:pending"` // pending, broadcasting, confirmed, failed
+	FailureReason string     `json:"failure_reason,omitempty"`
+	BlockHeight   uint64     `json:"block_height,omitempty"`
+	Confirmations uint       `json:"confirmations" gorm:"default:0"`
+	BroadcastAt   *time.Time `json:"broadcast_at,omitempty"`
+	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
+	Network       string     `json:"network" gorm:"not null"`
+	CryptoType    string     `json:"crypto_type" gorm:"not null"`
+	Memo          string     `json:"memo,omitempty"`
+
 	// Relationships
 	FromAddress CryptoAddress `json:"from_address,omitempty" gorm:"foreignKey:FromAddressID"`
 }
@@ -118,17 +140,21 @@ type CryptoWithdrawal struct {
 // Base CryptoUTXO model (mainly for Bitcoin-like cryptocurrencies)
 type CryptoUTXO struct {
 	gorm.Model
-	AddressID     uint   `json:"address_id" gorm:"not null;index"`
-	TxHash        string `json:"tx_hash" gorm:"not null"`
-	Vout          uint   `json:"vout" gorm:"not null"` // Output index
-	Amount        string `json:"amount" gorm:"type:decimal(28,18);not null"`
-	ScriptPubKey  string `json:"script_pub_key"`
-	IsSpent       bool   `json:"is_spent" gorm:"default:false"`
-	SpentTxHash   string `json:"spent_tx_hash,omitempty"`
-	SpentAt       *time.Time `json:"spent_at,omitempty"`
-	BlockHeight   uint64 `json:"block_height,omitempty"`
-	Confirmations uint   `json:"confirmations" gorm:"default:0"`
-	
+	AddressID               uint       `json:"address_id" gorm:"not null;index"`
+	TxHash                  string     `json:"tx_hash" gorm:"not null"`
+	Vout                    uint       `json:"vout" gorm:"not null"` // Output index
+	Amount                  string     `json:"amount" gorm:"type:decimal(28,18);not null"`
+	ScriptPubKey            string     `json:"script_pub_key"`
+	IsSpent                 bool       `json:"is_spent" gorm:"default:false"`
+	SpentTxHash             string     `json:"spent_tx_hash,omitempty"`
+	SpentAt                 *time.Time `json:"spent_at,omitempty"`
+	BlockHeight             uint64     `json:"block_height,omitempty"`
+	Confirmations           uint       `json:"confirmations" gorm:"default:0"`
+	ReservedForWithdrawalID *uint      `json:"reserved_for_withdrawal_id,omitempty" gorm:"index"` // locked while a withdrawal is being built/broadcast
+	IsOrphaned              bool       `json:"is_orphaned" gorm:"default:false"`                  // block it confirmed in was reorged out; excluded from coin selection
+	Network                 string     `json:"network" gorm:"not null"`
+	CryptoType              string     `json:"crypto_type" gorm:"not null"`
+
 	// Relationships
 	Address CryptoAddress `json:"address,omitempty" gorm:"foreignKey:AddressID"`
 }
@@ -137,21 +163,21 @@ type CryptoUTXO struct {
 type BitcoinTransaction struct {
 	CryptoTransaction
 	Size        uint   `json:"size,omitempty"`
-	VSize       uint   `json:"vsize,omitempty"`       // Virtual size (for SegWit)
-	Weight      uint   `json:"weight,omitempty"`      // Transaction weight
+	VSize       uint   `json:"vsize,omitempty"`  // Virtual size (for SegWit)
+	Weight      uint   `json:"weight,omitempty"` // Transaction weight
 	Version     uint   `json:"version" gorm:"default:1"`
 	LockTime    uint   `json:"lock_time" gorm:"default:0"`
 	InputCount  uint   `json:"input_count"`
 	OutputCount uint   `json:"output_count"`
 	FeeRate     string `json:"fee_rate,omitempty" gorm:"type:decimal(10,8)"` // sat/vB
-	RBF         bool   `json:"rbf" gorm:"default:false"` // Replace-by-fee
+	RBF         bool   `json:"rbf" gorm:"default:false"`                     // Replace-by-fee
 }
 
 // Bitcoin specific deposit
 type BitcoinDeposit struct {
 	CryptoDeposit
-	Vout         uint   `json:"vout"` // Output index in transaction
-	ScriptPubKey string `json:"script_pub_key,omitempty"`
+	Vout                  uint   `json:"vout"` // Output index in transaction
+	ScriptPubKey          string `json:"script_pub_key,omitempty"`
 	CoinbaseJustification string `json:"coinbase_justification,omitempty"` // If from coinbase tx
 }
 
@@ -172,11 +198,11 @@ type BitcoinWithdrawal struct {
 type EthereumTransaction struct {
 	CryptoTransaction
 	Nonce           uint64 `json:"nonce"`
-	GasPrice        string `json:"gas_price" gorm:"type:decimal(28,0)"`        // in wei
+	GasPrice        string `json:"gas_price" gorm:"type:decimal(28,0)"` // in wei
 	GasLimit        uint64 `json:"gas_limit"`
 	GasUsed         uint64 `json:"gas_used,omitempty"`
 	ContractAddress string `json:"contract_address,omitempty"`
-	Input           string `json:"input,omitempty" gorm:"type:text"`           // Contract call data
+	Input           string `json:"input,omitempty" gorm:"type:text"` // Contract call data
 	IsContract      bool   `json:"is_contract" gorm:"default:false"`
 	TokenTransfer   bool   `json:"token_transfer" gorm:"default:false"`
 	TokenSymbol     string `json:"token_symbol,omitempty"`
@@ -207,4 +233,65 @@ type EthereumWithdrawal struct {
 	TokenSymbol     string `json:"token_symbol,omitempty"`
 	TokenAmount     string `json:"token_amount,omitempty" gorm:"type:decimal(28,18)"`
 	Data            string `json:"data,omitempty" gorm:"type:text"` // Contract call data
-}
\ No newline at end of file
+}
+
+// Token is an ERC-20 contract the Ethereum indexer watches for Transfer
+// events, along with the metadata needed to scale raw on-chain amounts.
+type Token struct {
+	gorm.Model
+	ContractAddress string `json:"contract_address" gorm:"uniqueIndex:idx_token_contract_chain;not null"`
+	ChainID         uint64 `json:"chain_id" gorm:"uniqueIndex:idx_token_contract_chain;not null"`
+	Symbol          string `json:"symbol" gorm:"not null"`
+	Name            string `json:"name"`
+	Decimals        uint8  `json:"decimals" gorm:"not null"`
+	IsActive        bool   `json:"is_active" gorm:"default:true"`
+}
+
+// EventOutbox is written in the same GORM transaction as the domain state
+// change it describes; a background dispatcher (internal/events) publishes
+// each row to Kafka and marks it sent, giving at-least-once delivery with
+// IdempotencyKey as the dedup key downstream consumers key off of.
+type EventOutbox struct {
+	gorm.Model
+	Topic          string     `json:"topic" gorm:"not null;index"`
+	IdempotencyKey string     `json:"idempotency_key" gorm:"uniqueIndex:idx_outbox_topic_key;not null"`
+	EventType      string     `json:"event_type" gorm:"not null"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"` // JSON-encoded CloudEvents-style envelope
+	Status         string     `json:"status" gorm:"default:pending;index"`
+	Attempts       uint       `json:"attempts" gorm:"default:0"`
+	LastError      string     `json:"last_error,omitempty"`
+	SentAt         *time.Time `json:"sent_at,omitempty"`
+}
+
+// BridgeIntent tracks one cross-chain transfer: a confirmed CryptoDeposit on
+// SourceChain that the Committer mints (or withdraws, for ethereum->bitcoin)
+// as an equivalent amount on TargetChain. SourceDepositID is unique, so the
+// Indexer can enqueue idempotently across restarts, and the Committer skips
+// any intent that already carries a TargetTxHash rather than resubmitting.
+type BridgeIntent struct {
+	gorm.Model
+	UserID          uint       `json:"user_id" gorm:"not null;index"`
+	SourceChain     string     `json:"source_chain" gorm:"not null"` // bitcoin, ethereum
+	SourceDepositID uint       `json:"source_deposit_id" gorm:"uniqueIndex;not null"`
+	SourceTxHash    string     `json:"source_tx_hash" gorm:"not null"`
+	Recipient       string     `json:"recipient" gorm:"not null"` // address on TargetChain
+	Amount          string     `json:"amount" gorm:"type:decimal(28,18);not null"`
+	TargetChain     string     `json:"target_chain" gorm:"not null"`
+	TargetTxHash    string     `json:"target_tx_hash,omitempty"`
+	Status          string     `json:"status" gorm:"default:pending"` // pending, broadcasted, confirmed, failed
+	Attempts        uint       `json:"attempts" gorm:"default:0"`
+	FailureReason   string     `json:"failure_reason,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+}
+
+// ChainTip records one block of a chain's recent history so a monitor can
+// walk back to find a common ancestor and detect reorgs.
+type ChainTip struct {
+	gorm.Model
+	Network    string `json:"network" gorm:"not null;index"` // mainnet, testnet, regtest
+	CryptoType string `json:"crypto_type" gorm:"not null;index"`
+	Height     uint64 `json:"height" gorm:"not null;index"`
+	BlockHash  string `json:"block_hash" gorm:"not null"`
+	PrevHash   string `json:"prev_hash"`
+	IsOrphaned bool   `json:"is_orphaned" gorm:"default:false"`
+}
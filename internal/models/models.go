@@ -64,15 +64,20 @@ func AutoMigrate(db *gorm.DB) error {
 		// Core models
 		&User{},
 		&Account{},
-		
+
 		// Crypto wallet models
+		&HDSeed{},
 		&CryptoAddress{},
 		&CryptoTransaction{},
 		&CryptoDeposit{},
 		&CryptoWithdrawal{},
 		&CryptoUTXO{},
-		
-		// Extended crypto models  
+		&ChainTip{},
+		&Token{},
+		&EventOutbox{},
+		&BridgeIntent{},
+
+		// Extended crypto models
 		&BitcoinAddress{},
 		&EthereumAddress{},
 		&BitcoinTransaction{},
@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Decode decodes a Base58 string (no checksum validation) into its
+// big-endian byte payload, preserving leading '1' characters as 0x00 bytes.
+func base58Decode(s string) ([]byte, error) {
+	num := new(big.Int)
+	base := big.NewInt(58)
+	for _, c := range s {
+		idx := indexOfBase58(byte(c))
+		if idx < 0 {
+			return nil, fmt.Errorf("validate: invalid base58 character %q", c)
+		}
+		num.Mul(num, base)
+		num.Add(num, big.NewInt(int64(idx)))
+	}
+
+	decoded := num.Bytes()
+	leadingZeros := 0
+	for leadingZeros < len(s) && s[leadingZeros] == '1' {
+		leadingZeros++
+	}
+	out := make([]byte, leadingZeros+len(decoded))
+	copy(out[leadingZeros:], decoded)
+	return out, nil
+}
+
+func indexOfBase58(c byte) int {
+	for i := 0; i < len(base58Alphabet); i++ {
+		if base58Alphabet[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// doubleSHA256 is Bitcoin's standard hash-of-a-hash, used for Base58Check
+// checksums and elsewhere on the network.
+func doubleSHA256(b []byte) []byte {
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+// decodeBase58Check decodes a Base58Check string, verifying its trailing
+// 4-byte double-SHA256 checksum, and splits the remainder into its leading
+// version byte and payload.
+func decodeBase58Check(address string) (version byte, payload []byte, err error) {
+	decoded, err := base58Decode(address)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(decoded) < 5 {
+		return 0, nil, fmt.Errorf("validate: base58check payload too short")
+	}
+
+	body, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	want := doubleSHA256(body)[:4]
+	if !bytes.Equal(checksum, want) {
+		return 0, nil, fmt.Errorf("validate: base58check checksum mismatch")
+	}
+	return body[0], body[1:], nil
+}
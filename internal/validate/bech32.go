@@ -0,0 +1,165 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32Const and bech32mConst are the two checksum constants BIP173/BIP350
+// define; which one a valid address's checksum resolves to (via the BCH
+// polymod below) tells us whether it's a witness v0 (bech32) or v1+
+// (bech32m) address.
+const (
+	bech32Const  uint32 = 1
+	bech32mConst uint32 = 0x2bc830a3
+)
+
+// bech32Polymod is the BCH code polymod from BIP173.
+func bech32Polymod(values []byte) uint32 {
+	gen := []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// bech32HRPExpand expands hrp per BIP173 (high bits, a 0 separator, then low
+// bits) before it's mixed into the checksum polymod.
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// decodeBech32 splits addr into its HRP and 5-bit data words (with the
+// trailing 6-word checksum stripped), verifying the checksum and reporting
+// whether it matched the bech32 or bech32m constant.
+func decodeBech32(addr string) (hrp string, data []byte, isBech32m bool, err error) {
+	if addr != strings.ToLower(addr) && addr != strings.ToUpper(addr) {
+		return "", nil, false, fmt.Errorf("validate: bech32 address has mixed case")
+	}
+	lower := strings.ToLower(addr)
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 1 || sep+7 > len(lower) {
+		return "", nil, false, fmt.Errorf("validate: bech32 address missing separator")
+	}
+	hrp = lower[:sep]
+	dataPart := lower[sep+1:]
+
+	words := make([]byte, len(dataPart))
+	for i := 0; i < len(dataPart); i++ {
+		idx := strings.IndexByte(bech32Charset, dataPart[i])
+		if idx < 0 {
+			return "", nil, false, fmt.Errorf("validate: invalid bech32 character %q", dataPart[i])
+		}
+		words[i] = byte(idx)
+	}
+
+	values := append(bech32HRPExpand(hrp), words...)
+	switch bech32Polymod(values) {
+	case bech32Const:
+		isBech32m = false
+	case bech32mConst:
+		isBech32m = true
+	default:
+		return "", nil, false, fmt.Errorf("validate: bech32 checksum mismatch")
+	}
+	return hrp, words[:len(words)-6], isBech32m, nil
+}
+
+// convertBits re-packs a slice of fromBits-wide groups into toBits-wide
+// groups - used to turn the 5-bit bech32 data words into an 8-bit witness
+// program.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxVal := uint32(1<<toBits) - 1
+	var out []byte
+	for _, b := range data {
+		if uint32(b)>>fromBits != 0 {
+			return nil, fmt.Errorf("validate: invalid data range for bit conversion")
+		}
+		acc = acc<<fromBits | uint32(b)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte((acc>>bits)&maxVal))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte((acc<<(toBits-bits))&maxVal))
+		}
+	} else if bits >= fromBits || (acc<<(toBits-bits))&maxVal != 0 {
+		return nil, fmt.Errorf("validate: invalid padding in bit conversion")
+	}
+	return out, nil
+}
+
+// classifyBech32 decodes a Bech32/Bech32m segwit address expected to carry
+// hrp, checks its witness version against the correct checksum variant and
+// program length per BIP173/BIP350, and classifies the result.
+func classifyBech32(address, hrp string) (string, error) {
+	gotHRP, data, isBech32m, err := decodeBech32(address)
+	if err != nil {
+		return "", err
+	}
+	if gotHRP != hrp {
+		return "", fmt.Errorf("validate: bech32 hrp %q does not match expected %q", gotHRP, hrp)
+	}
+	if len(data) < 1 {
+		return "", fmt.Errorf("validate: bech32 address has no witness version")
+	}
+
+	witnessVersion := data[0]
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return "", fmt.Errorf("validate: decode witness program: %w", err)
+	}
+
+	if witnessVersion > 16 {
+		return "", fmt.Errorf("validate: invalid witness version %d", witnessVersion)
+	}
+
+	switch witnessVersion {
+	case 0:
+		if isBech32m {
+			return "", fmt.Errorf("validate: witness v0 address must use bech32, not bech32m")
+		}
+		switch len(program) {
+		case 20:
+			return models.AddressTypeP2WPKH, nil
+		case 32:
+			return models.AddressTypeP2WSH, nil
+		default:
+			return "", fmt.Errorf("validate: witness v0 program must be 20 or 32 bytes, got %d", len(program))
+		}
+	case 1:
+		if !isBech32m {
+			return "", fmt.Errorf("validate: witness v1 address must use bech32m, not bech32")
+		}
+		if len(program) != 32 {
+			return "", fmt.Errorf("validate: witness v1 (taproot) program must be 32 bytes, got %d", len(program))
+		}
+		return models.AddressTypeP2TR, nil
+	default:
+		return "", fmt.Errorf("validate: unsupported witness version %d", witnessVersion)
+	}
+}
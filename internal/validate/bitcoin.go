@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+)
+
+// base58Versions maps network -> (P2PKH version byte, P2SH version byte).
+var base58Versions = map[string][2]byte{
+	models.NetworkMainnet: {0x00, 0x05},
+	models.NetworkTestnet: {0x6F, 0xC4},
+	models.NetworkRegtest: {0x6F, 0xC4},
+}
+
+// bech32HRPs maps network -> its Bech32/Bech32m human-readable part.
+var bech32HRPs = map[string]string{
+	models.NetworkMainnet: "bc",
+	models.NetworkTestnet: "tb",
+	models.NetworkRegtest: "bcrt",
+}
+
+// BitcoinValidator implements models.CryptoValidator for Bitcoin (and the
+// Base58Check-compatible Litecoin/Dogecoin chains) addresses, private keys
+// and amounts on a single network.
+type BitcoinValidator struct {
+	Network string
+}
+
+// ValidateAddress reports whether address is a syntactically and
+// checksum-valid Base58Check or Bech32/Bech32m address for v.Network.
+func (v *BitcoinValidator) ValidateAddress(address string) bool {
+	_, err := ClassifyBitcoinAddress(address, v.Network)
+	return err == nil
+}
+
+// ValidatePrivateKey reports whether privateKey is a 32-byte hex scalar in
+// the valid secp256k1 range.
+func (v *BitcoinValidator) ValidatePrivateKey(privateKey string) bool {
+	return ValidatePrivateKeyScalar(privateKey)
+}
+
+// ValidateAmount reports whether amount is a strictly positive decimal
+// string, as stored on CryptoDeposit/CryptoWithdrawal.
+func (v *BitcoinValidator) ValidateAmount(amount string) bool {
+	return validatePositiveDecimal(amount)
+}
+
+// ValidateNetwork reports whether network is a Bitcoin network this
+// validator recognizes.
+func (v *BitcoinValidator) ValidateNetwork(network string) bool {
+	_, ok := base58Versions[network]
+	return ok
+}
+
+// ClassifyBitcoinAddress decodes address against network's expected
+// Base58Check version bytes and Bech32/Bech32m HRP, returning the
+// models.AddressType* constant it resolves to, or an error if address
+// matches neither encoding for network.
+func ClassifyBitcoinAddress(address, network string) (string, error) {
+	hrp, ok := bech32HRPs[network]
+	if ok {
+		if addrType, err := classifyBech32(address, hrp); err == nil {
+			return addrType, nil
+		}
+	}
+	return classifyBase58Check(address, network)
+}
+
+// classifyBase58Check decodes address as Base58Check and maps its version
+// byte to a P2PKH/P2SH address type for network.
+func classifyBase58Check(address, network string) (string, error) {
+	versions, ok := base58Versions[network]
+	if !ok {
+		return "", &invalidAddressError{"validate: unknown bitcoin network " + network}
+	}
+	version, _, err := decodeBase58Check(address)
+	if err != nil {
+		return "", err
+	}
+	switch version {
+	case versions[0]:
+		return models.AddressTypeP2PKH, nil
+	case versions[1]:
+		return models.AddressTypeP2SH, nil
+	default:
+		return "", &invalidAddressError{"validate: unrecognized base58check version byte for network " + network}
+	}
+}
+
+type invalidAddressError struct{ msg string }
+
+func (e *invalidAddressError) Error() string { return e.msg }
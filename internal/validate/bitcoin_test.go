@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+)
+
+func TestClassifyBitcoinAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		network string
+		want    string
+		wantErr bool
+	}{
+		{"mainnet P2PKH", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", models.NetworkMainnet, models.AddressTypeP2PKH, false},
+		{"mainnet P2SH", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", models.NetworkMainnet, models.AddressTypeP2SH, false},
+		{"mainnet P2WPKH", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", models.NetworkMainnet, models.AddressTypeP2WPKH, false},
+		{"mainnet P2TR", "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", models.NetworkMainnet, models.AddressTypeP2TR, false},
+		{"wrong network hrp", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", models.NetworkTestnet, "", true},
+		{"bad base58check checksum", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", models.NetworkMainnet, "", true},
+		{"garbage", "not-a-bitcoin-address", models.NetworkMainnet, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ClassifyBitcoinAddress(tt.address, tt.network)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got addrType=%q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("addrType = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBitcoinValidator_ValidateAddress(t *testing.T) {
+	v := &BitcoinValidator{Network: models.NetworkMainnet}
+	if !v.ValidateAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa") {
+		t.Error("expected valid P2PKH address to pass")
+	}
+	if v.ValidateAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb") {
+		t.Error("expected bad checksum address to fail")
+	}
+}
+
+func TestBitcoinValidator_ValidatePrivateKey(t *testing.T) {
+	v := &BitcoinValidator{Network: models.NetworkMainnet}
+	if !v.ValidatePrivateKey("0000000000000000000000000000000000000000000000000000000000000001") {
+		t.Error("expected 32-byte scalar 1 to pass")
+	}
+	if v.ValidatePrivateKey("0000000000000000000000000000000000000000000000000000000000000000") {
+		t.Error("expected scalar 0 to fail")
+	}
+	if v.ValidatePrivateKey("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141") {
+		t.Error("expected scalar == n to fail")
+	}
+}
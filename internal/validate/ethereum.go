@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// EthereumValidator implements models.CryptoValidator for Ethereum
+// addresses, private keys and amounts. Ethereum has a single network-agnostic
+// address format, so unlike BitcoinValidator it carries an optional ChainID
+// instead of a network: ChainID == 0 validates plain EIP-55 checksums only,
+// while a nonzero ChainID also accepts the EIP-1191 chain-ID-scoped variant.
+type EthereumValidator struct {
+	ChainID uint64
+}
+
+// NewEthereumValidator builds an EthereumValidator. Pass chainID == 0 to
+// validate EIP-55 checksums only; pass the target chain's ID to also accept
+// its EIP-1191 checksum variant.
+func NewEthereumValidator(chainID uint64) *EthereumValidator {
+	return &EthereumValidator{ChainID: chainID}
+}
+
+// ValidateAddress reports whether address is "0x" + 40 hex characters and,
+// if it isn't all-lowercase or all-uppercase, carries a valid EIP-55 (or,
+// when v.ChainID != 0, EIP-1191) checksum.
+func (v *EthereumValidator) ValidateAddress(address string) bool {
+	hexPart, err := parseHexAddress(address)
+	if err != nil {
+		return false
+	}
+	if isAllSameCase(hexPart) {
+		// All-lowercase or all-uppercase addresses are valid but unchecksummed
+		// per EIP-55 - the spec only constrains mixed-case input.
+		return true
+	}
+	if address[2:] == checksumAddress(strings.ToLower(hexPart), 0) {
+		return true
+	}
+	if v.ChainID != 0 && address[2:] == checksumAddress(strings.ToLower(hexPart), v.ChainID) {
+		return true
+	}
+	return false
+}
+
+// ValidatePrivateKey reports whether privateKey is a 32-byte hex scalar in
+// the valid secp256k1 range.
+func (v *EthereumValidator) ValidatePrivateKey(privateKey string) bool {
+	return ValidatePrivateKeyScalar(privateKey)
+}
+
+// ValidateAmount reports whether amount is a strictly positive decimal
+// string, as stored on CryptoDeposit/CryptoWithdrawal.
+func (v *EthereumValidator) ValidateAmount(amount string) bool {
+	return validatePositiveDecimal(amount)
+}
+
+// ValidateNetwork reports whether network is an Ethereum network name this
+// validator recognizes.
+func (v *EthereumValidator) ValidateNetwork(network string) bool {
+	switch network {
+	case "mainnet", "ropsten", "goerli", "sepolia":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseHexAddress strips the "0x" prefix from address and validates it is
+// exactly 40 hex characters, returning the hex part (without "0x").
+func parseHexAddress(address string) (string, error) {
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return "", fmt.Errorf("validate: ethereum address must be 0x-prefixed and 40 hex characters")
+	}
+	hexPart := address[2:]
+	for _, c := range hexPart {
+		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')) {
+			return "", fmt.Errorf("validate: invalid hex character %q in ethereum address", c)
+		}
+	}
+	return hexPart, nil
+}
+
+// isAllSameCase reports whether hexPart's letters are entirely lowercase or
+// entirely uppercase (digits never affect the result), the EIP-55 exception
+// for unchecksummed addresses.
+func isAllSameCase(hexPart string) bool {
+	return hexPart == strings.ToLower(hexPart) || hexPart == strings.ToUpper(hexPart)
+}
+
+// checksumAddress applies EIP-55 (chainID == 0) or EIP-1191 (chainID != 0)
+// to lowerHex, an already-lowercased 40-character hex address body, and
+// returns the mixed-case result.
+func checksumAddress(lowerHex string, chainID uint64) string {
+	var preimage string
+	if chainID != 0 {
+		preimage = fmt.Sprintf("%d0x%s", chainID, lowerHex)
+	} else {
+		preimage = lowerHex
+	}
+	hash := ethcrypto.Keccak256([]byte(preimage))
+
+	out := make([]byte, len(lowerHex))
+	for i := 0; i < len(lowerHex); i++ {
+		c := lowerHex[i]
+		if c >= '0' && c <= '9' {
+			out[i] = c
+			continue
+		}
+		// hash nibble for character i: high nibble of byte i/2 for even i,
+		// low nibble for odd i.
+		var nibble byte
+		if i%2 == 0 {
+			nibble = hash[i/2] >> 4
+		} else {
+			nibble = hash[i/2] & 0x0f
+		}
+		if nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		} else {
+			out[i] = c
+		}
+	}
+	return string(out)
+}
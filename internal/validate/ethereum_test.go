@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEthereumValidator_ValidateAddress(t *testing.T) {
+	v := NewEthereumValidator(0)
+
+	cases := []struct {
+		name    string
+		address string
+		want    bool
+	}{
+		{"valid EIP-55 checksum", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{"all lowercase unchecksummed", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true},
+		{"all uppercase unchecksummed", "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", true},
+		{"wrong mixed-case checksum", "0x5aAEb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{"too short", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA", false},
+		{"missing 0x prefix", "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{"invalid hex", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAzz", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v.ValidateAddress(tt.address)
+			if got != tt.want {
+				t.Errorf("ValidateAddress(%q) = %v, want %v", tt.address, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEthereumValidator_EIP1191ChainIDVariant(t *testing.T) {
+	lowerHex := "27b1fdb04752bbc536007a920d24acb045561c26"
+	eip1191 := "0x" + checksumAddress(lowerHex, 30)
+	eip55 := "0x" + checksumAddress(lowerHex, 0)
+
+	chainAware := NewEthereumValidator(30)
+	if !chainAware.ValidateAddress(eip1191) {
+		t.Errorf("expected EIP-1191 checksum %q to validate under chain ID 30", eip1191)
+	}
+	if !chainAware.ValidateAddress(eip55) {
+		t.Errorf("expected plain EIP-55 checksum %q to still validate", eip55)
+	}
+
+	if eip1191 != eip55 {
+		chainUnaware := NewEthereumValidator(0)
+		if chainUnaware.ValidateAddress(eip1191) {
+			t.Errorf("expected chain-ID-0 validator to reject the EIP-1191-only checksum %q", eip1191)
+		}
+	}
+}
+
+func TestChecksumAddress_KnownEIP55Vectors(t *testing.T) {
+	// Vectors from EIP-55.
+	vectors := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+	for _, want := range vectors {
+		got := "0x" + checksumAddress(strings.ToLower(want[2:]), 0)
+		if got != want {
+			t.Errorf("checksumAddress(%q) = %q, want %q", want, got, want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package validate
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+// secp256k1Order is n, the order of the secp256k1 base point (SEC2 section
+// 2.4.1). A valid private key scalar must be in [1, n-1].
+var secp256k1Order, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+
+// ValidatePrivateKeyScalar reports whether privateKey (hex, "0x" prefix
+// optional) decodes to exactly 32 bytes and is strictly between 0 and the
+// secp256k1 group order n - the range every Bitcoin and Ethereum signing
+// key must fall in.
+func ValidatePrivateKeyScalar(privateKey string) bool {
+	raw, err := hex.DecodeString(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil || len(raw) != 32 {
+		return false
+	}
+	scalar := new(big.Int).SetBytes(raw)
+	return scalar.Sign() > 0 && scalar.Cmp(secp256k1Order) < 0
+}
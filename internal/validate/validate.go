@@ -0,0 +1,38 @@
+// Package validate implements models.CryptoValidator: per-chain address
+// syntax/checksum validation (Base58Check + Bech32/Bech32m for Bitcoin,
+// EIP-55/EIP-1191 for Ethereum), secp256k1 private-key scalar range checks,
+// and the address classification used to auto-populate
+// BitcoinAddress.AddressType.
+package validate
+
+import (
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+// NewValidator builds a models.CryptoValidator scoped to one chain +
+// network, e.g. NewValidator(models.CryptoTypeBitcoin, models.NetworkTestnet).
+// Ethereum addresses aren't network-specific, so network is ignored for
+// models.CryptoTypeEthereum; use NewEthereumValidator directly for the
+// EIP-1191 chain-id variant.
+func NewValidator(cryptoType, network string) (models.CryptoValidator, error) {
+	switch cryptoType {
+	case models.CryptoTypeBitcoin, models.CryptoTypeLitecoin, models.CryptoTypeDogecoin:
+		return &BitcoinValidator{Network: network}, nil
+	case models.CryptoTypeEthereum:
+		return NewEthereumValidator(0), nil
+	default:
+		return nil, models.CryptoError{Code: models.ErrNetworkError, Message: "validate: unsupported crypto type " + cryptoType}
+	}
+}
+
+// validatePositiveDecimal reports whether amount parses as a strictly
+// positive decimal string, the shape every CryptoDeposit/CryptoWithdrawal
+// amount field uses.
+func validatePositiveDecimal(amount string) bool {
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return false
+	}
+	return d.Sign() > 0
+}
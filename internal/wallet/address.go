@@ -0,0 +1,59 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+)
+
+// netParams resolves the btcsuite chain params for a network name.
+func netParams(network string) (*chaincfg.Params, error) {
+	switch network {
+	case models.NetworkMainnet:
+		return &chaincfg.MainNetParams, nil
+	case models.NetworkTestnet:
+		return &chaincfg.TestNet3Params, nil
+	case models.NetworkRegtest:
+		return &chaincfg.RegressionNetParams, nil
+	default:
+		return nil, fmt.Errorf("wallet: unsupported bitcoin network %q", network)
+	}
+}
+
+// bitcoinAddress derives the native SegWit (P2WPKH) address for a private key.
+func bitcoinAddress(priv *btcec.PrivateKey, network string) (address, pubKeyHex string, err error) {
+	params, err := netParams(network)
+	if err != nil {
+		return "", "", err
+	}
+	pubKeyHash := btcutil.Hash160(priv.PubKey().SerializeCompressed())
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, params)
+	if err != nil {
+		return "", "", fmt.Errorf("wallet: build P2WPKH address: %w", err)
+	}
+	return addr.EncodeAddress(), fmt.Sprintf("%x", priv.PubKey().SerializeCompressed()), nil
+}
+
+// ethereumAddress derives the checksummed Ethereum address for a private key.
+func ethereumAddress(priv *btcec.PrivateKey) (address, pubKeyHex string, err error) {
+	ecdsaPriv := priv.ToECDSA()
+	addr := ethcrypto.PubkeyToAddress(ecdsaPriv.PublicKey)
+	return addr.Hex(), fmt.Sprintf("%x", ethcrypto.FromECDSAPub(&ecdsaPriv.PublicKey)), nil
+}
+
+// deriveChainAddress produces the address/pubkey pair for a crypto type
+// from a derived BIP32 key.
+func deriveChainAddress(priv *btcec.PrivateKey, cryptoType, network string) (address, pubKeyHex string, err error) {
+	switch cryptoType {
+	case models.CryptoTypeBitcoin, models.CryptoTypeLitecoin, models.CryptoTypeDogecoin:
+		return bitcoinAddress(priv, network)
+	case models.CryptoTypeEthereum:
+		return ethereumAddress(priv)
+	default:
+		return "", "", fmt.Errorf("wallet: unsupported crypto type %q", cryptoType)
+	}
+}
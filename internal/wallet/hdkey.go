@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+)
+
+// BIP44 purpose/coin-type constants.
+const (
+	purposeBIP44 = 44
+	coinTypeBTC  = 0
+	coinTypeETH  = 60
+	coinTypeTBTC = 1 // testnet/regtest BTC, per SLIP-44
+)
+
+// coinType picks the SLIP-44 coin type for a crypto type + network pair.
+func coinType(cryptoType, network string) (uint32, error) {
+	switch cryptoType {
+	case models.CryptoTypeBitcoin, models.CryptoTypeLitecoin, models.CryptoTypeDogecoin:
+		if network == models.NetworkMainnet {
+			return coinTypeBTC, nil
+		}
+		return coinTypeTBTC, nil
+	case models.CryptoTypeEthereum:
+		return coinTypeETH, nil
+	default:
+		return 0, fmt.Errorf("wallet: unsupported crypto type %q", cryptoType)
+	}
+}
+
+// DerivationPath returns the BIP44 path for an account/change/index tuple,
+// e.g. m/44'/0'/0'/0/5.
+func DerivationPath(cryptoType, network string, account, change, index uint32) (string, error) {
+	ct, err := coinType(cryptoType, network)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("m/%d'/%d'/%d'/%d/%d", purposeBIP44, ct, account, change, index), nil
+}
+
+// masterKeyFromSeed derives the BIP32 master extended key (HMAC-SHA512 over
+// "Bitcoin seed", per BIP32) from a 64-byte BIP39 seed.
+func masterKeyFromSeed(seed []byte) (*hdkeychain.ExtendedKey, error) {
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, fmt.Errorf("wallet: derive master key: %w", err)
+	}
+	return master, nil
+}
+
+// derivedKey walks a master extended key down a BIP44
+// purpose'/coin'/account'/change/index path. Indices at or above
+// hdkeychain.HardenedKeyStart are hardened.
+func derivedKey(master *hdkeychain.ExtendedKey, cryptoType, network string, account, change, index uint32) (*hdkeychain.ExtendedKey, error) {
+	ct, err := coinType(cryptoType, network)
+	if err != nil {
+		return nil, err
+	}
+
+	hardened := hdkeychain.HardenedKeyStart
+	steps := []uint32{
+		purposeBIP44 + uint32(hardened),
+		ct + uint32(hardened),
+		account + uint32(hardened),
+		change,
+		index,
+	}
+
+	key := master
+	for _, step := range steps {
+		key, err = key.Derive(step)
+		if err != nil {
+			return nil, fmt.Errorf("wallet: derive step %d: %w", step, err)
+		}
+	}
+	return key, nil
+}
+
+// parseDerivationPath extracts the account/change/index tuple from a
+// "m/44'/coin'/account'/change/index" path, as stored in
+// BitcoinAddress.Derivation.
+func parseDerivationPath(path string) (account, change, index uint32, err error) {
+	parts := strings.Split(strings.TrimPrefix(path, "m/"), "/")
+	if len(parts) != 5 {
+		return 0, 0, 0, fmt.Errorf("wallet: malformed derivation path %q", path)
+	}
+	account, err = parseIndexComponent(parts[2])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	change, err = parseIndexComponent(parts[3])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	index, err = parseIndexComponent(parts[4])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return account, change, index, nil
+}
+
+func parseIndexComponent(component string) (uint32, error) {
+	n, err := strconv.ParseUint(strings.TrimSuffix(component, "'"), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("wallet: malformed derivation path component %q: %w", component, err)
+	}
+	return uint32(n), nil
+}
+
+// ecPrivKey extracts the underlying secp256k1 private key from a derived
+// extended key.
+func ecPrivKey(key *hdkeychain.ExtendedKey) (*btcec.PrivateKey, error) {
+	if !key.IsPrivate() {
+		return nil, fmt.Errorf("wallet: extended key is not a private key")
+	}
+	return key.ECPrivKey()
+}
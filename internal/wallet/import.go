@@ -0,0 +1,50 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+// parseImportedKey accepts a BIP32 extended private key (xprv/tprv), a WIF,
+// or a raw hex-encoded secp256k1 scalar and returns the resulting private
+// key plus the address/pubkey it derives for cryptoType/network.
+func parseImportedKey(rawKey, cryptoType, network string) (*btcec.PrivateKey, string, string, error) {
+	priv, err := decodeImportedKey(rawKey)
+	if err != nil {
+		return nil, "", "", err
+	}
+	address, pubKeyHex, err := deriveChainAddress(priv, cryptoType, network)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return priv, address, pubKeyHex, nil
+}
+
+func decodeImportedKey(rawKey string) (*btcec.PrivateKey, error) {
+	if strings.HasPrefix(rawKey, "xprv") || strings.HasPrefix(rawKey, "tprv") {
+		key, err := hdkeychain.NewKeyFromString(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("wallet: parse extended private key: %w", err)
+		}
+		return ecPrivKey(key)
+	}
+
+	if wif, err := btcutil.DecodeWIF(rawKey); err == nil {
+		return wif.PrivKey, nil
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(rawKey, "0x"))
+	if err != nil || len(raw) != 32 {
+		return nil, fmt.Errorf("wallet: unrecognized private key format")
+	}
+	priv, _ := btcec.PrivKeyFromBytes(raw)
+	if priv == nil {
+		return nil, fmt.Errorf("wallet: private key scalar out of range")
+	}
+	return priv, nil
+}
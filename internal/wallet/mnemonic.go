@@ -0,0 +1,59 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// entropyBits maps the requested word count to the BIP39 entropy size.
+func entropyBits(wordCount uint) (int, error) {
+	switch wordCount {
+	case 12:
+		return 128, nil
+	case 24:
+		return 256, nil
+	default:
+		return 0, fmt.Errorf("wallet: unsupported mnemonic word count %d (want 12 or 24)", wordCount)
+	}
+}
+
+// NewMnemonic generates a fresh BIP39 mnemonic with the given word count
+// (12 for 128-bit entropy, 24 for 256-bit entropy).
+func NewMnemonic(wordCount uint) (string, error) {
+	bits, err := entropyBits(wordCount)
+	if err != nil {
+		return "", err
+	}
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", fmt.Errorf("wallet: generate entropy: %w", err)
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("wallet: build mnemonic: %w", err)
+	}
+	return mnemonic, nil
+}
+
+// SeedFromMnemonic derives the 64-byte BIP39 seed from a mnemonic and
+// optional passphrase via PBKDF2-HMAC-SHA512 (2048 iterations), as specified
+// by BIP39. The mnemonic is validated against the English wordlist checksum
+// before derivation.
+func SeedFromMnemonic(mnemonic, passphrase string) ([]byte, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("wallet: invalid mnemonic")
+	}
+	return bip39.NewSeed(mnemonic, passphrase), nil
+}
+
+// randomBytes is a small helper kept local so callers don't need to import
+// crypto/rand directly.
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("wallet: read random bytes: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	envMasterKey = "WALLET_MASTER_KEY"
+	kdfSaltLen   = 16
+	kdfKeyLen    = 32 // AES-256
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+)
+
+// masterKey reads the operator-supplied master key from the environment.
+// The seed encryption key is never derived from anything stored in the DB.
+func masterKey() ([]byte, error) {
+	mk := os.Getenv(envMasterKey)
+	if mk == "" {
+		return nil, fmt.Errorf("wallet: %s is not set", envMasterKey)
+	}
+	return []byte(mk), nil
+}
+
+// derivedAESKey runs scrypt over the master key and a random per-secret salt
+// to produce the AES-256 key used to encrypt a user's HD seed or an
+// imported private key.
+func derivedAESKey(salt []byte) ([]byte, error) {
+	mk, err := masterKey()
+	if err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(mk, salt, scryptN, scryptR, scryptP, kdfKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("wallet: derive seed key: %w", err)
+	}
+	return key, nil
+}
+
+// encryptSecret seals arbitrary secret bytes (an HD seed or an imported
+// private key) with AES-256-GCM under a key derived from the master key and
+// a fresh random salt. The ciphertext, GCM nonce, and salt must all be
+// persisted together to allow decryption later.
+func encryptSecret(secret []byte) (ciphertext, nonce, salt []byte, err error) {
+	salt, err = randomBytes(kdfSaltLen)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, err := derivedAESKey(salt)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("wallet: new AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("wallet: new GCM: %w", err)
+	}
+	nonce, err = randomBytes(gcm.NonceSize())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ciphertext = gcm.Seal(nil, nonce, secret, nil)
+	return ciphertext, nonce, salt, nil
+}
+
+// decryptSecret reverses encryptSecret.
+func decryptSecret(ciphertext, nonce, salt []byte) ([]byte, error) {
+	key, err := derivedAESKey(salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("wallet: new AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("wallet: new GCM: %w", err)
+	}
+	secret, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("wallet: decrypt secret: %w", err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,138 @@
+package wallet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// getOrCreateSeed returns the user's existing HD seed, generating and
+// persisting a new one if none exists yet.
+func (s *Service) getOrCreateSeed(userID uint, wordCount uint, passphrase string) (*models.HDSeed, error) {
+	var existing models.HDSeed
+	err := s.db.Where("user_id = ?", userID).First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("wallet: load HD seed: %w", err)
+	}
+
+	mnemonic, err := NewMnemonic(wordCount)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := SeedFromMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storeSeed(userID, seed, wordCount); err != nil {
+		return nil, err
+	}
+	return s.getSeedRow(userID)
+}
+
+// storeSeed encrypts and upserts a raw 64-byte BIP39 seed for a user.
+func (s *Service) storeSeed(userID uint, seed []byte, wordCount uint) error {
+	ciphertext, nonce, salt, err := encryptSecret(seed)
+	if err != nil {
+		return fmt.Errorf("wallet: encrypt seed: %w", err)
+	}
+
+	row := models.HDSeed{
+		UserID:     userID,
+		Ciphertext: ciphertext,
+		Nonce:      nonce,
+		KDFSalt:    salt,
+		WordCount:  wordCount,
+	}
+	return s.db.Where("user_id = ?", userID).
+		Assign(row).
+		FirstOrCreate(&row).Error
+}
+
+func (s *Service) getSeedRow(userID uint) (*models.HDSeed, error) {
+	var row models.HDSeed
+	if err := s.db.Where("user_id = ?", userID).First(&row).Error; err != nil {
+		return nil, fmt.Errorf("wallet: load HD seed: %w", err)
+	}
+	return &row, nil
+}
+
+// getSeed loads and decrypts a user's HD seed.
+func (s *Service) getSeed(userID uint) ([]byte, error) {
+	row, err := s.getSeedRow(userID)
+	if err != nil {
+		return nil, err
+	}
+	return decryptSecret(row.Ciphertext, row.Nonce, row.KDFSalt)
+}
+
+// nextIndex returns the next unused (non-hardened) BIP44 address index for
+// a user's chain-specific counter.
+func (s *Service) nextIndex(userID uint, cryptoType string) (uint32, error) {
+	row, err := s.getSeedRow(userID)
+	if err != nil {
+		return 0, err
+	}
+	switch cryptoType {
+	case models.CryptoTypeBitcoin, models.CryptoTypeLitecoin, models.CryptoTypeDogecoin:
+		return row.NextBitcoinIx, nil
+	case models.CryptoTypeEthereum:
+		return row.NextEthereumIx, nil
+	default:
+		return 0, fmt.Errorf("wallet: unsupported crypto type %q", cryptoType)
+	}
+}
+
+// advanceIndex persists the next index to use for a user's chain-specific
+// counter, inside the caller's transaction.
+func (s *Service) advanceIndex(tx *gorm.DB, userID uint, cryptoType string, next uint32) error {
+	var column string
+	switch cryptoType {
+	case models.CryptoTypeBitcoin, models.CryptoTypeLitecoin, models.CryptoTypeDogecoin:
+		column = "next_bitcoin_index"
+	case models.CryptoTypeEthereum:
+		column = "next_ethereum_index"
+	default:
+		return fmt.Errorf("wallet: unsupported crypto type %q", cryptoType)
+	}
+	return tx.Model(&models.HDSeed{}).
+		Where("user_id = ?", userID).
+		Update(column, next).Error
+}
+
+// packSecret concatenates ciphertext/nonce/salt with length prefixes so they
+// can be stored in the single EncryptedPrivateKey blob column used for
+// imported (non-HD) keys.
+func packSecret(ciphertext, nonce, salt []byte) []byte {
+	buf := make([]byte, 0, 12+len(ciphertext)+len(nonce)+len(salt))
+	var lenBuf [4]byte
+	for _, part := range [][]byte{ciphertext, nonce, salt} {
+		binary.BigEndian.PutUint32(lenBuf[:], uint32(len(part)))
+		buf = append(buf, lenBuf[:]...)
+		buf = append(buf, part...)
+	}
+	return buf
+}
+
+// unpackSecret reverses packSecret.
+func unpackSecret(blob []byte) (ciphertext, nonce, salt []byte, err error) {
+	parts := make([][]byte, 0, 3)
+	for i := 0; i < 3; i++ {
+		if len(blob) < 4 {
+			return nil, nil, nil, fmt.Errorf("wallet: truncated secret blob")
+		}
+		n := binary.BigEndian.Uint32(blob[:4])
+		blob = blob[4:]
+		if uint32(len(blob)) < n {
+			return nil, nil, nil, fmt.Errorf("wallet: truncated secret blob")
+		}
+		parts = append(parts, blob[:n])
+		blob = blob[n:]
+	}
+	return parts[0], parts[1], parts[2], nil
+}
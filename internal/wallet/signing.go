@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// PrivateKeyFor derives (for HD addresses) or decrypts (for imported
+// addresses) the signing key for a CryptoAddress, just-in-time. Callers are
+// responsible for zeroing the key once signing is complete.
+func (s *Service) PrivateKeyFor(addressID uint) (*btcec.PrivateKey, error) {
+	var record models.CryptoAddress
+	if err := s.db.First(&record, addressID).Error; err != nil {
+		return nil, fmt.Errorf("wallet: load address %d: %w", addressID, err)
+	}
+
+	if len(record.EncryptedPrivateKey) > 0 {
+		return s.privateKeyFromBlob(record.EncryptedPrivateKey)
+	}
+	return s.privateKeyFromHDPath(&record)
+}
+
+func (s *Service) privateKeyFromBlob(blob []byte) (*btcec.PrivateKey, error) {
+	ciphertext, nonce, salt, err := unpackSecret(blob)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := decryptSecret(ciphertext, nonce, salt)
+	if err != nil {
+		return nil, err
+	}
+	priv, _ := btcec.PrivKeyFromBytes(raw)
+	if priv == nil {
+		return nil, fmt.Errorf("wallet: decrypted key is out of range")
+	}
+	return priv, nil
+}
+
+func (s *Service) privateKeyFromHDPath(record *models.CryptoAddress) (*btcec.PrivateKey, error) {
+	derivation, err := s.loadDerivation(record)
+	if err != nil || derivation == "" {
+		return nil, errors.New("wallet: address has neither an imported key nor a recorded HD derivation path")
+	}
+
+	account, change, index, err := parseDerivationPath(derivation)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := s.getSeed(record.UserID)
+	if err != nil {
+		return nil, err
+	}
+	master, err := masterKeyFromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	key, err := derivedKey(master, record.CryptoType, record.Network, account, change, index)
+	if err != nil {
+		return nil, err
+	}
+	return ecPrivKey(key)
+}
+
+// loadDerivation looks up the HD derivation path recorded for record,
+// reading from whichever per-crypto-type address table actually stores it.
+func (s *Service) loadDerivation(record *models.CryptoAddress) (string, error) {
+	switch record.CryptoType {
+	case models.CryptoTypeEthereum:
+		var ethAddr models.EthereumAddress
+		if err := s.db.Where("id = ?", record.ID).First(&ethAddr).Error; err != nil {
+			return "", nil
+		}
+		return ethAddr.Derivation, nil
+	default:
+		var btcAddr models.BitcoinAddress
+		if err := s.db.Where("id = ?", record.ID).First(&btcAddr).Error; err != nil {
+			return "", nil
+		}
+		return btcAddr.Derivation, nil
+	}
+}
@@ -0,0 +1,229 @@
+// Package wallet implements models.WalletService as a BIP32/BIP39/BIP44
+// hierarchical-deterministic wallet. Each user has at most one encrypted
+// seed (models.HDSeed); every address is derived from it on demand and no
+// private key is ever persisted in cleartext.
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/validate"
+	"gorm.io/gorm"
+)
+
+const defaultMnemonicWords = 24
+
+// Service implements models.WalletService on top of GORM-backed storage.
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService constructs a wallet Service. WALLET_MASTER_KEY must be set in
+// the environment before any seed is encrypted or decrypted.
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// CreateWallet generates a brand-new HD seed for the user (if one does not
+// already exist) and derives the first address for cryptoType/network.
+func (s *Service) CreateWallet(userID uint, cryptoType string, network string) (models.AddressManager, error) {
+	if _, err := s.getOrCreateSeed(userID, defaultMnemonicWords, ""); err != nil {
+		return nil, err
+	}
+	return s.deriveNext(userID, cryptoType, network)
+}
+
+// ImportWallet imports an existing wallet either from a BIP39 mnemonic
+// (space-separated words) or from a raw WIF/hex private key. A mnemonic
+// import replaces/creates the user's HD seed and derives the first address;
+// a raw key import stores a single non-HD address with its key encrypted at
+// rest.
+func (s *Service) ImportWallet(userID uint, privateKey string, cryptoType string, network string) (models.AddressManager, error) {
+	trimmed := strings.TrimSpace(privateKey)
+	if strings.Contains(trimmed, " ") {
+		return s.importMnemonic(userID, trimmed, cryptoType, network)
+	}
+	return s.importRawKey(userID, trimmed, cryptoType, network)
+}
+
+func (s *Service) importMnemonic(userID uint, mnemonic, cryptoType, network string) (models.AddressManager, error) {
+	seed, err := SeedFromMnemonic(mnemonic, "")
+	if err != nil {
+		return nil, models.CryptoError{Code: models.ErrInvalidPrivateKey, Message: "invalid mnemonic", Details: err.Error()}
+	}
+	wordCount := uint(12)
+	if len(strings.Fields(mnemonic)) > 12 {
+		wordCount = 24
+	}
+	if err := s.storeSeed(userID, seed, wordCount); err != nil {
+		return nil, err
+	}
+	return s.deriveNext(userID, cryptoType, network)
+}
+
+func (s *Service) importRawKey(userID uint, rawKey, cryptoType, network string) (models.AddressManager, error) {
+	priv, address, pubKeyHex, err := parseImportedKey(rawKey, cryptoType, network)
+	if err != nil {
+		return nil, models.CryptoError{Code: models.ErrInvalidPrivateKey, Message: "invalid private key", Details: err.Error()}
+	}
+	ciphertext, nonce, salt, err := encryptSecret(priv.Serialize())
+	if err != nil {
+		return nil, fmt.Errorf("wallet: encrypt imported key: %w", err)
+	}
+
+	record := &models.CryptoAddress{
+		UserID:              userID,
+		Address:             address,
+		PublicKey:           pubKeyHex,
+		EncryptedPrivateKey: packSecret(ciphertext, nonce, salt),
+		Network:             network,
+		CryptoType:          cryptoType,
+		IsActive:            true,
+	}
+	if err := s.db.Create(record).Error; err != nil {
+		return nil, fmt.Errorf("wallet: persist imported address: %w", err)
+	}
+	return &addressManager{db: s.db, record: record}, nil
+}
+
+// DeriveNext derives the next BIP44 address (account 0, external chain, the
+// next unused non-hardened index) for a user's existing HD seed, using
+// mainnet. Callers that need testnet/regtest addresses should go through
+// CreateWallet/ImportWallet instead, which take an explicit network.
+func (s *Service) DeriveNext(userID uint, cryptoType string) (models.AddressManager, error) {
+	return s.deriveNext(userID, cryptoType, models.NetworkMainnet)
+}
+
+// deriveNext is the network-aware implementation shared by DeriveNext,
+// CreateWallet, and mnemonic imports.
+func (s *Service) deriveNext(userID uint, cryptoType, network string) (models.AddressManager, error) {
+	seed, err := s.getSeed(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	master, err := masterKeyFromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.nextIndex(userID, cryptoType)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := derivedKey(master, cryptoType, network, 0, 0, index)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := ecPrivKey(key)
+	if err != nil {
+		return nil, err
+	}
+	address, pubKeyHex, err := deriveChainAddress(priv, cryptoType, network)
+	if err != nil {
+		return nil, err
+	}
+	path, err := DerivationPath(cryptoType, network, 0, 0, index)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.CryptoAddress{
+		UserID:     userID,
+		Address:    address,
+		PublicKey:  pubKeyHex,
+		Network:    network,
+		CryptoType: cryptoType,
+		IsActive:   true,
+	}
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return fmt.Errorf("wallet: persist derived address: %w", err)
+		}
+		if cryptoType == models.CryptoTypeBitcoin || cryptoType == models.CryptoTypeLitecoin || cryptoType == models.CryptoTypeDogecoin {
+			addressType, err := validate.ClassifyBitcoinAddress(address, network)
+			if err != nil {
+				return fmt.Errorf("wallet: classify derived address: %w", err)
+			}
+			btcRecord := &models.BitcoinAddress{CryptoAddress: *record, Derivation: path, AddressType: addressType}
+			if err := tx.Create(btcRecord).Error; err != nil {
+				return fmt.Errorf("wallet: persist bitcoin address: %w", err)
+			}
+		} else if cryptoType == models.CryptoTypeEthereum {
+			ethRecord := &models.EthereumAddress{CryptoAddress: *record, Derivation: path}
+			if err := tx.Create(ethRecord).Error; err != nil {
+				return fmt.Errorf("wallet: persist ethereum address: %w", err)
+			}
+		}
+		return s.advanceIndex(tx, userID, cryptoType, index+1)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &addressManager{db: s.db, record: record}, nil
+}
+
+// GetWallet loads an address manager for an existing CryptoAddress row.
+func (s *Service) GetWallet(addressID uint) (models.AddressManager, error) {
+	var record models.CryptoAddress
+	if err := s.db.First(&record, addressID).Error; err != nil {
+		return nil, fmt.Errorf("wallet: load address %d: %w", addressID, err)
+	}
+	return &addressManager{db: s.db, record: &record}, nil
+}
+
+// ListWallets returns address managers for every active address of a given
+// crypto type belonging to a user.
+func (s *Service) ListWallets(userID uint, cryptoType string) ([]models.AddressManager, error) {
+	var records []models.CryptoAddress
+	if err := s.db.Where("user_id = ? AND crypto_type = ?", userID, cryptoType).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("wallet: list addresses: %w", err)
+	}
+	managers := make([]models.AddressManager, 0, len(records))
+	for i := range records {
+		managers = append(managers, &addressManager{db: s.db, record: &records[i]})
+	}
+	return managers, nil
+}
+
+// addressManager implements models.AddressManager for a single, already
+// derived CryptoAddress row.
+type addressManager struct {
+	db     *gorm.DB
+	record *models.CryptoAddress
+}
+
+// GenerateAddress is a no-op for HD/imported addresses; the address was
+// already generated at derivation/import time. It only validates that the
+// requested network matches what was persisted.
+func (m *addressManager) GenerateAddress(network string) error {
+	if m.record.Network != network {
+		return models.CryptoError{Code: models.ErrNetworkError, Message: "address was generated for a different network"}
+	}
+	return nil
+}
+
+// ValidateAddress reports whether the supplied address matches this manager.
+func (m *addressManager) ValidateAddress(address string) bool {
+	return address == m.record.Address
+}
+
+// GetBalance returns the last-synced balance as base units (satoshi/wei).
+func (m *addressManager) GetBalance(ctx context.Context) (*big.Int, error) {
+	bal, ok := new(big.Int).SetString(m.record.Balance, 10)
+	if !ok {
+		return big.NewInt(0), nil
+	}
+	return bal, nil
+}
+
+// SyncBalance reloads the address row; actual on-chain balance updates are
+// written by the chain monitors (internal/chains/...).
+func (m *addressManager) SyncBalance(ctx context.Context) error {
+	return m.db.WithContext(ctx).First(m.record, m.record.ID).Error
+}
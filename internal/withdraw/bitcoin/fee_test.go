@@ -0,0 +1,34 @@
+package bitcoin
+
+import "testing"
+
+func TestEstimateVSize_ScriptTypesDiffer(t *testing.T) {
+	// Witness inputs are discounted under BIP141, so a P2WPKH tx must come
+	// out smaller than the equivalent legacy P2PKH tx for the same shape.
+	p2pkh := estimateVSize(1, 2, ScriptP2PKH)
+	p2wpkh := estimateVSize(1, 2, ScriptP2WPKH)
+	p2tr := estimateVSize(1, 2, ScriptP2TR)
+
+	if p2wpkh >= p2pkh {
+		t.Fatalf("expected p2wpkh vsize (%d) < p2pkh vsize (%d)", p2wpkh, p2pkh)
+	}
+	if p2tr >= p2pkh {
+		t.Fatalf("expected p2tr vsize (%d) < p2pkh vsize (%d)", p2tr, p2pkh)
+	}
+}
+
+func TestEstimateFee_ScalesWithFeeRate(t *testing.T) {
+	low := estimateFee(2, 1, ScriptP2WPKH, 5)
+	high := estimateFee(2, 1, ScriptP2WPKH, 20)
+	if high != 4*low {
+		t.Fatalf("expected fee to scale linearly with sat/vB: low=%d high=%d", low, high)
+	}
+}
+
+func TestEstimateFee_MoreInputsCostMore(t *testing.T) {
+	one := estimateFee(1, 1, ScriptP2WPKH, 10)
+	two := estimateFee(2, 1, ScriptP2WPKH, 10)
+	if two <= one {
+		t.Fatalf("expected a 2-input tx to cost more than a 1-input tx: one=%d two=%d", one, two)
+	}
+}
@@ -0,0 +1,158 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	chainbtc "github.com/geoffreyhinton/crypto_trading_v2/internal/chains/bitcoin"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/wallet"
+)
+
+// psbtInput is one selected UTXO plus the data needed to build and sign its
+// PSBT input entry.
+type psbtInput struct {
+	utxo       models.CryptoUTXO
+	addressID  uint
+	scriptType ScriptType
+	pkScript   []byte
+	amountSats int64
+}
+
+// buildPSBT assembles an unsigned BIP174 packet: one input per selected
+// UTXO, a recipient output, and (if non-zero) a change output back to
+// changeAddress.
+func buildPSBT(params *chaincfg.Params, inputs []psbtInput, toAddress string, amountSats int64, changeAddress string, changeSats int64) (*psbt.Packet, error) {
+	tx := wire.NewMsgTx(2)
+
+	for _, in := range inputs {
+		hash, err := chainhash.NewHashFromStr(in.utxo.TxHash)
+		if err != nil {
+			return nil, fmt.Errorf("bitcoin: parse utxo txid %s: %w", in.utxo.TxHash, err)
+		}
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(hash, in.utxo.Vout), nil, nil))
+	}
+
+	toScript, err := addressToScript(toAddress, params)
+	if err != nil {
+		return nil, fmt.Errorf("bitcoin: recipient address: %w", err)
+	}
+	tx.AddTxOut(wire.NewTxOut(amountSats, toScript))
+
+	if changeSats > 0 {
+		changeScript, err := addressToScript(changeAddress, params)
+		if err != nil {
+			return nil, fmt.Errorf("bitcoin: change address: %w", err)
+		}
+		tx.AddTxOut(wire.NewTxOut(changeSats, changeScript))
+	}
+
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return nil, fmt.Errorf("bitcoin: build psbt: %w", err)
+	}
+
+	for i, in := range inputs {
+		packet.Inputs[i].WitnessUtxo = wire.NewTxOut(in.amountSats, in.pkScript)
+		packet.Inputs[i].SighashType = txscript.SigHashAll
+	}
+
+	return packet, nil
+}
+
+// p2wpkhScriptCode derives the BIP143 scriptCode for a P2WPKH input from its
+// witness program (`0014<hash20>`): the legacy P2PKH script
+// `OP_DUP OP_HASH160 <hash20> OP_EQUALVERIFY OP_CHECKSIG`, not the witness
+// program itself.
+func p2wpkhScriptCode(witnessProgram []byte) ([]byte, error) {
+	if len(witnessProgram) != 22 || witnessProgram[0] != 0x00 || witnessProgram[1] != 0x14 {
+		return nil, fmt.Errorf("not a v0 P2WPKH witness program: %x", witnessProgram)
+	}
+	hash160 := witnessProgram[2:]
+	return txscript.NewScriptBuilder().
+		AddOp(txscript.OP_DUP).
+		AddOp(txscript.OP_HASH160).
+		AddData(hash160).
+		AddOp(txscript.OP_EQUALVERIFY).
+		AddOp(txscript.OP_CHECKSIG).
+		Script()
+}
+
+// addressToScript decodes a Bitcoin address string into its output script.
+func addressToScript(address string, params *chaincfg.Params) ([]byte, error) {
+	addr, err := btcutil.DecodeAddress(address, params)
+	if err != nil {
+		return nil, fmt.Errorf("decode address %q: %w", address, err)
+	}
+	return txscript.PayToAddrScript(addr)
+}
+
+// signAndFinalize signs every PSBT input locally with its just-in-time
+// derived key (ECDSA for P2WPKH, Schnorr/BIP340 for P2TR via
+// RawTxInTaprootSignature), finalizes the packet, and returns the fully
+// signed raw transaction hex ready for broadcast.
+func signAndFinalize(packet *psbt.Packet, inputs []psbtInput, w *wallet.Service) (string, error) {
+	tx := packet.UnsignedTx
+	prevOutFetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, in := range inputs {
+		prevOutFetcher.AddPrevOut(tx.TxIn[i].PreviousOutPoint, packet.Inputs[i].WitnessUtxo)
+		_ = in
+	}
+	sigHashes := txscript.NewTxSigHashes(tx, prevOutFetcher)
+
+	for i, in := range inputs {
+		priv, err := w.PrivateKeyFor(in.addressID)
+		if err != nil {
+			return "", fmt.Errorf("bitcoin: derive signing key for input %d: %w", i, err)
+		}
+
+		switch in.scriptType {
+		case ScriptP2TR:
+			sig, err := txscript.RawTxInTaprootSignature(tx, sigHashes, i, in.amountSats, in.pkScript, txscript.SigHashDefault, priv)
+			if err != nil {
+				return "", fmt.Errorf("bitcoin: schnorr-sign input %d: %w", i, err)
+			}
+			packet.Inputs[i].TaprootKeySpendSig = sig
+		default: // P2WPKH
+			scriptCode, err := p2wpkhScriptCode(in.pkScript)
+			if err != nil {
+				return "", fmt.Errorf("bitcoin: scriptCode for input %d: %w", i, err)
+			}
+			sig, err := txscript.RawTxInWitnessSignature(tx, sigHashes, i, in.amountSats, scriptCode, txscript.SigHashAll, priv)
+			if err != nil {
+				return "", fmt.Errorf("bitcoin: sign input %d: %w", i, err)
+			}
+			packet.Inputs[i].PartialSigs = append(packet.Inputs[i].PartialSigs, &psbt.PartialSig{
+				PubKey:    priv.PubKey().SerializeCompressed(),
+				Signature: sig,
+			})
+		}
+	}
+
+	if err := psbt.MaybeFinalizeAll(packet); err != nil {
+		return "", fmt.Errorf("bitcoin: finalize psbt: %w", err)
+	}
+
+	finalTx, err := psbt.Extract(packet)
+	if err != nil {
+		return "", fmt.Errorf("bitcoin: extract final tx: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := finalTx.Serialize(&buf); err != nil {
+		return "", fmt.Errorf("bitcoin: serialize final tx: %w", err)
+	}
+	return fmt.Sprintf("%x", buf.Bytes()), nil
+}
+
+// broadcast submits a signed raw transaction hex to Bitcoin Core.
+func broadcast(ctx context.Context, rpc *chainbtc.RPCClient, rawTxHex string) (string, error) {
+	return rpc.SendRawTransaction(ctx, rawTxHex)
+}
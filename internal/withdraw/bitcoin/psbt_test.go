@@ -0,0 +1,56 @@
+package bitcoin
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+)
+
+const testTxHash = "1111111111111111111111111111111111111111111111111111111111111111"
+
+func regtestAddress(t *testing.T, seed byte) string {
+	t.Helper()
+	hash := [20]byte{}
+	hash[0] = seed
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(hash[:], &chaincfg.RegressionNetParams)
+	if err != nil {
+		t.Fatalf("build test address: %v", err)
+	}
+	return addr.EncodeAddress()
+}
+
+func TestBuildPSBT_RecipientAndChangeOutputs(t *testing.T) {
+	inputs := []psbtInput{
+		{utxo: models.CryptoUTXO{TxHash: testTxHash, Vout: 0}, amountSats: 100000},
+	}
+
+	packet, err := buildPSBT(&chaincfg.RegressionNetParams, inputs, regtestAddress(t, 1), 50000, regtestAddress(t, 2), 49000)
+	if err != nil {
+		t.Fatalf("buildPSBT: %v", err)
+	}
+	if len(packet.UnsignedTx.TxOut) != 2 {
+		t.Fatalf("expected recipient + change outputs, got %d", len(packet.UnsignedTx.TxOut))
+	}
+	if packet.UnsignedTx.TxOut[0].Value != 50000 {
+		t.Fatalf("expected recipient output of 50000 sats, got %d", packet.UnsignedTx.TxOut[0].Value)
+	}
+	if packet.UnsignedTx.TxOut[1].Value != 49000 {
+		t.Fatalf("expected change output of 49000 sats, got %d", packet.UnsignedTx.TxOut[1].Value)
+	}
+}
+
+func TestBuildPSBT_NoChangeOutputWhenDustFoldedIntoFee(t *testing.T) {
+	inputs := []psbtInput{
+		{utxo: models.CryptoUTXO{TxHash: testTxHash, Vout: 1}, amountSats: 100000},
+	}
+
+	packet, err := buildPSBT(&chaincfg.RegressionNetParams, inputs, regtestAddress(t, 1), 99000, regtestAddress(t, 2), 0)
+	if err != nil {
+		t.Fatalf("buildPSBT: %v", err)
+	}
+	if len(packet.UnsignedTx.TxOut) != 1 {
+		t.Fatalf("expected zero change to produce a single recipient output, got %d", len(packet.UnsignedTx.TxOut))
+	}
+}
@@ -0,0 +1,185 @@
+// Package bitcoin implements models.WithdrawalService for Bitcoin: UTXO
+// coin selection, fee estimation, and a BIP174 PSBT construct/sign/broadcast
+// pipeline.
+package bitcoin
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+)
+
+// randSeed seeds the single-random-draw shuffle off the clock; coin
+// selection has no need for a cryptographically secure source.
+func randSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// ScriptType identifies the spending script of a UTXO, which determines its
+// input weight for fee/vsize calculations.
+type ScriptType string
+
+const (
+	ScriptP2PKH  ScriptType = models.AddressTypeP2PKH
+	ScriptP2WPKH ScriptType = models.AddressTypeP2WPKH
+	ScriptP2TR   ScriptType = models.AddressTypeP2TR
+)
+
+// MinChangeSatoshis is the smallest change output this package will ever
+// create; anything below it is folded into the fee instead (dust avoidance).
+const MinChangeSatoshis = 1000
+
+// CoinSelector picks which confirmed, unlocked UTXOs fund a withdrawal.
+type CoinSelector interface {
+	// SelectCoins returns the chosen UTXOs and the change amount (satoshis)
+	// left over after paying targetAmount + the estimated fee.
+	SelectCoins(candidates []models.CryptoUTXO, targetAmount *big.Int, feeRateSatPerVByte int64, scriptType ScriptType) (selected []models.CryptoUTXO, change *big.Int, err error)
+}
+
+// eligible filters candidates down to confirmed, unspent, unlocked UTXOs,
+// sorted by amount descending.
+func eligible(candidates []models.CryptoUTXO, requiredConfirms uint) []models.CryptoUTXO {
+	out := make([]models.CryptoUTXO, 0, len(candidates))
+	for _, u := range candidates {
+		if u.IsSpent || u.IsOrphaned || u.Confirmations < requiredConfirms {
+			continue
+		}
+		out = append(out, u)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return satoshisOf(out[i]) > satoshisOf(out[j])
+	})
+	return out
+}
+
+func satoshisOf(u models.CryptoUTXO) int64 {
+	amt, ok := new(big.Float).SetString(u.Amount)
+	if !ok {
+		return 0
+	}
+	sats, _ := new(big.Float).Mul(amt, big.NewFloat(1e8)).Int64()
+	return sats
+}
+
+// BranchAndBoundSelector implements Bitcoin Core's branch-and-bound coin
+// selection: a DFS over UTXOs (sorted largest-first) looking for a subset
+// whose total falls within [target, target+costOfChange] so no change
+// output is needed at all. It gives up and reports no match if the search
+// space is exhausted, letting the caller fall back to KnapsackSelector.
+type BranchAndBoundSelector struct {
+	RequiredConfirms uint
+	// CostOfChangeSatoshis is the upper slack allowed above the exact
+	// target before a subset is rejected; it approximates the cost of
+	// creating and later spending a change output.
+	CostOfChangeSatoshis int64
+	MaxTries             int
+}
+
+// SelectCoins implements CoinSelector.
+func (s BranchAndBoundSelector) SelectCoins(candidates []models.CryptoUTXO, targetAmount *big.Int, feeRateSatPerVByte int64, scriptType ScriptType) ([]models.CryptoUTXO, *big.Int, error) {
+	pool := eligible(candidates, s.RequiredConfirms)
+	target := targetAmount.Int64()
+	maxTries := s.MaxTries
+	if maxTries == 0 {
+		maxTries = 100000
+	}
+	costOfChange := s.CostOfChangeSatoshis
+	if costOfChange == 0 {
+		costOfChange = vbytesForOutputs(1, scriptType) * feeRateSatPerVByte
+	}
+
+	var best []int
+	bestWaste := int64(-1)
+	current := make([]int, 0, len(pool))
+	var currentSum int64
+	tries := 0
+
+	var dfs func(index int)
+	dfs = func(index int) {
+		tries++
+		if tries > maxTries {
+			return
+		}
+		fee := estimateFee(len(current), 1, scriptType, feeRateSatPerVByte)
+		if currentSum >= target+fee {
+			waste := currentSum - target - fee
+			if waste <= costOfChange && (bestWaste == -1 || waste < bestWaste) {
+				bestWaste = waste
+				best = append([]int(nil), current...)
+			}
+			return
+		}
+		if index >= len(pool) {
+			return
+		}
+		// Include pool[index].
+		current = append(current, index)
+		currentSum += satoshisOf(pool[index])
+		dfs(index + 1)
+		currentSum -= satoshisOf(pool[index])
+		current = current[:len(current)-1]
+		// Exclude pool[index].
+		dfs(index + 1)
+	}
+	dfs(0)
+
+	if best == nil {
+		return nil, nil, fmt.Errorf("bitcoin: branch-and-bound found no exact-ish match")
+	}
+
+	selected := make([]models.CryptoUTXO, 0, len(best))
+	var total int64
+	for _, i := range best {
+		selected = append(selected, pool[i])
+		total += satoshisOf(pool[i])
+	}
+	fee := estimateFee(len(selected), 1, scriptType, feeRateSatPerVByte)
+	change := total - target - fee
+	if change < 0 {
+		change = 0
+	}
+	if change < MinChangeSatoshis {
+		change = 0 // fold dust change into the fee
+	}
+	return selected, big.NewInt(change), nil
+}
+
+// KnapsackSelector is the single-random-draw fallback: it shuffles the
+// eligible UTXO set and accumulates coins until target+fee is met, the
+// classic Bitcoin Core "knapsack"/SRD approach used when branch-and-bound
+// can't find an exact match.
+type KnapsackSelector struct {
+	RequiredConfirms uint
+	Rand             *rand.Rand // nil uses the package-level default source
+}
+
+// SelectCoins implements CoinSelector.
+func (s KnapsackSelector) SelectCoins(candidates []models.CryptoUTXO, targetAmount *big.Int, feeRateSatPerVByte int64, scriptType ScriptType) ([]models.CryptoUTXO, *big.Int, error) {
+	pool := eligible(candidates, s.RequiredConfirms)
+	r := s.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(randSeed()))
+	}
+	r.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+	target := targetAmount.Int64()
+	var selected []models.CryptoUTXO
+	var total int64
+	for _, u := range pool {
+		selected = append(selected, u)
+		total += satoshisOf(u)
+		fee := estimateFee(len(selected), 1, scriptType, feeRateSatPerVByte)
+		if total >= target+fee {
+			change := total - target - fee
+			if change < MinChangeSatoshis {
+				change = 0 // fold dust change into the fee
+			}
+			return selected, big.NewInt(change), nil
+		}
+	}
+	return nil, nil, fmt.Errorf("bitcoin: insufficient confirmed funds for withdrawal")
+}
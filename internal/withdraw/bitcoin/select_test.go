@@ -0,0 +1,130 @@
+package bitcoin
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+)
+
+func utxo(amountBTC string, confirmations uint) models.CryptoUTXO {
+	return models.CryptoUTXO{Amount: amountBTC, Confirmations: confirmations}
+}
+
+func TestBranchAndBoundSelector_ExactMatch(t *testing.T) {
+	// Two UTXOs sum to exactly target+fee for a 1-in/1-out tx at 10 sat/vB,
+	// so BnB should find a change-free match and return zero change.
+	candidates := []models.CryptoUTXO{
+		utxo("0.00050000", 6), // 50,000 sats
+		utxo("0.00030000", 6), // 30,000 sats
+	}
+	fee := estimateFee(1, 1, ScriptP2WPKH, 10)
+	target := big.NewInt(50000 - fee)
+
+	s := BranchAndBoundSelector{RequiredConfirms: 6}
+	selected, change, err := s.SelectCoins(candidates, target, 10, ScriptP2WPKH)
+	if err != nil {
+		t.Fatalf("SelectCoins: %v", err)
+	}
+	if len(selected) != 1 {
+		t.Fatalf("expected a single exact-match utxo, got %d", len(selected))
+	}
+	if change.Sign() != 0 {
+		t.Fatalf("expected zero change from an exact match, got %s", change)
+	}
+}
+
+func TestBranchAndBoundSelector_NoMatchFallsBackToKnapsack(t *testing.T) {
+	// No subset of these UTXOs lands within the cost-of-change slack of the
+	// target, so BnB must report no match and the caller falls back to
+	// KnapsackSelector (mirroring service.go's CreateWithdrawal).
+	candidates := []models.CryptoUTXO{
+		utxo("0.00100000", 6), // 100,000 sats
+	}
+	target := big.NewInt(1000) // tiny target, huge leftover -> no exact-ish match
+
+	s := BranchAndBoundSelector{RequiredConfirms: 6}
+	if _, _, err := s.SelectCoins(candidates, target, 10, ScriptP2WPKH); err == nil {
+		t.Fatalf("expected branch-and-bound to find no match")
+	}
+
+	knapsack := KnapsackSelector{RequiredConfirms: 6, Rand: rand.New(rand.NewSource(1))}
+	selected, change, err := knapsack.SelectCoins(candidates, target, 10, ScriptP2WPKH)
+	if err != nil {
+		t.Fatalf("knapsack fallback: %v", err)
+	}
+	if len(selected) != 1 {
+		t.Fatalf("expected knapsack to select the only utxo, got %d", len(selected))
+	}
+	if change.Sign() <= 0 {
+		t.Fatalf("expected positive change from the knapsack fallback, got %s", change)
+	}
+}
+
+func TestBranchAndBoundSelector_DustChangeFoldedIntoFee(t *testing.T) {
+	// A single UTXO well outside BnB's cost-of-change slack falls through to
+	// its post-search change calculation; leftover just under
+	// MinChangeSatoshis must be folded into the fee rather than creating a
+	// dust change output (mirrors the Knapsack dust-fold below).
+	candidates := []models.CryptoUTXO{
+		utxo("0.00100000", 6), // 100,000 sats
+	}
+	fee := estimateFee(1, 1, ScriptP2WPKH, 1)
+	target := big.NewInt(100000 - fee - (MinChangeSatoshis - 1))
+
+	s := BranchAndBoundSelector{RequiredConfirms: 6, CostOfChangeSatoshis: MinChangeSatoshis}
+	_, change, err := s.SelectCoins(candidates, target, 1, ScriptP2WPKH)
+	if err != nil {
+		t.Fatalf("SelectCoins: %v", err)
+	}
+	if change.Sign() != 0 {
+		t.Fatalf("expected sub-dust change to be folded into the fee, got %s", change)
+	}
+}
+
+func TestKnapsackSelector_DustChangeFoldedIntoFee(t *testing.T) {
+	// Leftover just under MinChangeSatoshis must be folded into the fee
+	// rather than creating a dust change output.
+	candidates := []models.CryptoUTXO{
+		utxo("0.00100000", 6), // 100,000 sats
+	}
+	fee := estimateFee(1, 1, ScriptP2WPKH, 1)
+	target := big.NewInt(100000 - fee - (MinChangeSatoshis - 1))
+
+	s := KnapsackSelector{RequiredConfirms: 6, Rand: rand.New(rand.NewSource(1))}
+	_, change, err := s.SelectCoins(candidates, target, 1, ScriptP2WPKH)
+	if err != nil {
+		t.Fatalf("SelectCoins: %v", err)
+	}
+	if change.Sign() != 0 {
+		t.Fatalf("expected sub-dust change to be folded into the fee, got %s", change)
+	}
+}
+
+func TestKnapsackSelector_InsufficientFunds(t *testing.T) {
+	candidates := []models.CryptoUTXO{
+		utxo("0.00010000", 6), // 10,000 sats
+	}
+	target := big.NewInt(1000000) // far more than available
+
+	s := KnapsackSelector{RequiredConfirms: 6, Rand: rand.New(rand.NewSource(1))}
+	if _, _, err := s.SelectCoins(candidates, target, 10, ScriptP2WPKH); err == nil {
+		t.Fatalf("expected an insufficient-funds error")
+	}
+}
+
+func TestEligible_ExcludesUnconfirmedAndSpent(t *testing.T) {
+	candidates := []models.CryptoUTXO{
+		utxo("0.00010000", 6),
+		utxo("0.00020000", 1), // below RequiredConfirms
+		{Amount: "0.00030000", Confirmations: 6, IsSpent: true},
+	}
+	out := eligible(candidates, 6)
+	if len(out) != 1 {
+		t.Fatalf("expected only the single confirmed, unspent utxo to remain, got %d", len(out))
+	}
+	if out[0].Amount != "0.00010000" {
+		t.Fatalf("unexpected utxo survived filtering: %+v", out[0])
+	}
+}
@@ -0,0 +1,366 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	chainbtc "github.com/geoffreyhinton/crypto_trading_v2/internal/chains/bitcoin"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/models"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/validate"
+	"github.com/geoffreyhinton/crypto_trading_v2/internal/wallet"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const confirmationTarget = 6 // blocks; fed to estimatesmartfee
+
+// EventPublisher is the minimal hook the service calls on withdrawal state
+// changes. internal/events provides a Kafka-backed implementation; nil is a
+// valid no-op. tx is the same transaction the state change was just written
+// in, so an outbox-pattern implementation can enqueue atomically with it.
+type EventPublisher interface {
+	PublishWithdrawalCreated(ctx context.Context, tx *gorm.DB, idempotencyKey string, withdrawal *models.CryptoWithdrawal) error
+	PublishWithdrawalBroadcast(ctx context.Context, tx *gorm.DB, idempotencyKey string, withdrawal *models.CryptoWithdrawal) error
+	PublishWithdrawalConfirmed(ctx context.Context, tx *gorm.DB, idempotencyKey string, withdrawal *models.CryptoWithdrawal) error
+}
+
+// Service implements models.WithdrawalService for Bitcoin: coin selection,
+// fee estimation, and PSBT construct/sign/broadcast.
+type Service struct {
+	db        *gorm.DB
+	rpc       *chainbtc.RPCClient
+	wallet    *wallet.Service
+	feeEst    *FeeEstimator
+	cfg       chainbtc.Config
+	logger    *logrus.Logger
+	publisher EventPublisher
+}
+
+// NewService builds a withdrawal Service. publisher may be nil.
+func NewService(db *gorm.DB, cfg chainbtc.Config, w *wallet.Service, logger *logrus.Logger, publisher EventPublisher) *Service {
+	rpc := chainbtc.NewRPCClient(cfg)
+	return &Service{
+		db:        db,
+		rpc:       rpc,
+		wallet:    w,
+		feeEst:    NewFeeEstimator(rpc),
+		cfg:       cfg,
+		logger:    logger,
+		publisher: publisher,
+	}
+}
+
+// withdrawalIdempotencyKey scopes a dedup key to both the withdrawal row
+// and the lifecycle transition being published, so create/broadcast/confirm
+// events for the same withdrawal don't collide.
+func withdrawalIdempotencyKey(withdrawalID uint, transition string) string {
+	return fmt.Sprintf("withdrawal:%d:%s", withdrawalID, transition)
+}
+
+func (s *Service) netParams() (*chaincfg.Params, error) {
+	switch s.cfg.NetworkName {
+	case models.NetworkMainnet:
+		return &chaincfg.MainNetParams, nil
+	case models.NetworkTestnet:
+		return &chaincfg.TestNet3Params, nil
+	case models.NetworkRegtest:
+		return &chaincfg.RegressionNetParams, nil
+	default:
+		return nil, fmt.Errorf("bitcoin: unsupported network %q", s.cfg.NetworkName)
+	}
+}
+
+// CreateWithdrawal selects coins, estimates a fee, and persists a pending
+// BitcoinWithdrawal row, atomically reserving the chosen UTXOs so a
+// concurrent withdrawal can't double-spend them.
+func (s *Service) CreateWithdrawal(fromAddressID uint, toAddress string, amount *big.Int) (models.CryptoWithdrawal, error) {
+	ctx := context.Background()
+
+	if _, err := validate.ClassifyBitcoinAddress(toAddress, s.cfg.NetworkName); err != nil {
+		return models.CryptoWithdrawal{}, models.CryptoError{
+			Code:    models.ErrInvalidAddress,
+			Message: "bitcoin: invalid withdrawal destination address",
+			Details: err.Error(),
+		}
+	}
+
+	var from models.CryptoAddress
+	if err := s.db.First(&from, fromAddressID).Error; err != nil {
+		return models.CryptoWithdrawal{}, fmt.Errorf("bitcoin: load from-address %d: %w", fromAddressID, err)
+	}
+	var fromBTC models.BitcoinAddress
+	if err := s.db.Where("id = ?", fromAddressID).First(&fromBTC).Error; err != nil {
+		return models.CryptoWithdrawal{}, fmt.Errorf("bitcoin: load from-address bitcoin details: %w", err)
+	}
+	scriptType := ScriptType(fromBTC.AddressType)
+	if scriptType == "" {
+		scriptType = ScriptP2WPKH
+	}
+
+	var candidates []models.CryptoUTXO
+	if err := s.db.Where("address_id = ? AND is_spent = ? AND is_orphaned = ? AND reserved_for_withdrawal_id IS NULL", fromAddressID, false, false).
+		Find(&candidates).Error; err != nil {
+		return models.CryptoWithdrawal{}, fmt.Errorf("bitcoin: load utxos: %w", err)
+	}
+
+	feeRate, err := s.feeEst.EstimateSatPerVByte(ctx, confirmationTarget)
+	if err != nil {
+		return models.CryptoWithdrawal{}, err
+	}
+
+	bnb := BranchAndBoundSelector{RequiredConfirms: s.cfg.RequiredConfirms}
+	selected, change, err := bnb.SelectCoins(candidates, amount, feeRate, scriptType)
+	if err != nil {
+		knapsack := KnapsackSelector{RequiredConfirms: s.cfg.RequiredConfirms}
+		selected, change, err = knapsack.SelectCoins(candidates, amount, feeRate, scriptType)
+		if err != nil {
+			return models.CryptoWithdrawal{}, fmt.Errorf("bitcoin: %w", models.CryptoError{Code: models.ErrInsufficientFunds, Message: err.Error()})
+		}
+	}
+
+	// The selector sized fee/change for a single (no-change) output. Adding a
+	// change output costs extra vbytes, so re-derive the fee for 2 outputs and
+	// fold the difference out of change; if that leaves sub-dust change, drop
+	// the change output entirely and absorb it into the fee instead, keeping
+	// Fee/VSize consistent with whatever OutputCount actually gets used.
+	outputCount := 1
+	fee := estimateFee(len(selected), outputCount, scriptType, feeRate)
+	vsize := estimateVSize(len(selected), outputCount, scriptType)
+	if change.Sign() > 0 {
+		twoOutputFee := estimateFee(len(selected), 2, scriptType, feeRate)
+		adjustedChange := change.Int64() - (twoOutputFee - fee)
+		if adjustedChange >= MinChangeSatoshis {
+			outputCount = 2
+			fee = twoOutputFee
+			vsize = estimateVSize(len(selected), outputCount, scriptType)
+			change = big.NewInt(adjustedChange)
+		} else {
+			fee += change.Int64()
+			change = big.NewInt(0)
+		}
+	}
+
+	withdrawal := models.BitcoinWithdrawal{
+		CryptoWithdrawal: models.CryptoWithdrawal{
+			FromAddressID: fromAddressID,
+			ToAddress:     toAddress,
+			Amount:        satoshisToBTCString(amount.Int64()),
+			Fee:           satoshisToBTCString(fee),
+			Status:        models.StatusPending,
+			Network:       s.cfg.NetworkName,
+			CryptoType:    models.CryptoTypeBitcoin,
+		},
+		ChangeAddress: from.Address,
+		ChangeAmount:  satoshisToBTCString(change.Int64()),
+		FeeRate:       strconv.FormatInt(feeRate, 10),
+		InputCount:    uint(len(selected)),
+		OutputCount:   uint(outputCount),
+		VSize:         uint(vsize),
+		RBF:           true,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&withdrawal).Error; err != nil {
+			return fmt.Errorf("bitcoin: persist withdrawal: %w", err)
+		}
+		for _, u := range selected {
+			if err := tx.Model(&models.CryptoUTXO{}).Where("id = ? AND is_spent = ? AND reserved_for_withdrawal_id IS NULL", u.ID, false).
+				Update("reserved_for_withdrawal_id", withdrawal.ID).Error; err != nil {
+				return fmt.Errorf("bitcoin: reserve utxo %d: %w", u.ID, err)
+			}
+		}
+		if s.publisher != nil {
+			key := withdrawalIdempotencyKey(withdrawal.ID, "created")
+			if err := s.publisher.PublishWithdrawalCreated(ctx, tx, key, &withdrawal.CryptoWithdrawal); err != nil {
+				s.logger.WithError(err).Warn("bitcoin: publish withdrawal-created event failed")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.CryptoWithdrawal{}, err
+	}
+
+	return withdrawal.CryptoWithdrawal, nil
+}
+
+// ProcessWithdrawal builds and signs the PSBT for a pending withdrawal,
+// leaving it ready to broadcast.
+func (s *Service) ProcessWithdrawal(withdrawalID uint) error {
+	withdrawal, reserved, err := s.loadWithdrawalAndUTXOs(withdrawalID)
+	if err != nil {
+		return err
+	}
+
+	params, err := s.netParams()
+	if err != nil {
+		return err
+	}
+
+	var fromBTC models.BitcoinAddress
+	if err := s.db.Where("id = ?", withdrawal.FromAddressID).First(&fromBTC).Error; err != nil {
+		return fmt.Errorf("bitcoin: load from-address: %w", err)
+	}
+	scriptType := ScriptType(fromBTC.AddressType)
+	if scriptType == "" {
+		scriptType = ScriptP2WPKH
+	}
+
+	inputs := make([]psbtInput, 0, len(reserved))
+	for _, u := range reserved {
+		pkScript, err := hex.DecodeString(u.ScriptPubKey)
+		if err != nil {
+			return fmt.Errorf("bitcoin: decode scriptPubKey for utxo %d: %w", u.ID, err)
+		}
+		inputs = append(inputs, psbtInput{
+			utxo:       u,
+			addressID:  withdrawal.FromAddressID,
+			scriptType: scriptType,
+			pkScript:   pkScript,
+			amountSats: btcStringToSatoshis(u.Amount),
+		})
+	}
+
+	amountSats := btcStringToSatoshis(withdrawal.Amount)
+	changeSats := btcStringToSatoshis(withdrawal.ChangeAmount)
+
+	packet, err := buildPSBT(params, inputs, withdrawal.ToAddress, amountSats, withdrawal.ChangeAddress, changeSats)
+	if err != nil {
+		return err
+	}
+	rawTxHex, err := signAndFinalize(packet, inputs, s.wallet)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Model(&models.CryptoWithdrawal{}).Where("id = ?", withdrawalID).
+		Update("signed_raw_tx", rawTxHex).Error
+}
+
+// BroadcastWithdrawal submits the previously signed raw transaction and
+// records the resulting txid.
+func (s *Service) BroadcastWithdrawal(withdrawalID uint) error {
+	ctx := context.Background()
+	var withdrawal models.CryptoWithdrawal
+	if err := s.db.First(&withdrawal, withdrawalID).Error; err != nil {
+		return fmt.Errorf("bitcoin: load withdrawal %d: %w", withdrawalID, err)
+	}
+	rawTxHex := withdrawal.SignedRawTx
+	if rawTxHex == "" {
+		return fmt.Errorf("bitcoin: withdrawal %d has not been processed/signed yet", withdrawalID)
+	}
+
+	txid, err := broadcast(ctx, s.rpc, rawTxHex)
+	if err != nil {
+		s.db.Model(&models.CryptoWithdrawal{}).Where("id = ?", withdrawalID).
+			Updates(map[string]interface{}{"status": models.StatusFailed, "failure_reason": err.Error()})
+		return fmt.Errorf("bitcoin: broadcast withdrawal %d: %w", withdrawalID, err)
+	}
+
+	now := time.Now()
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CryptoWithdrawal{}).Where("id = ?", withdrawalID).
+			Updates(map[string]interface{}{"tx_hash": txid, "status": models.StatusBroadcasted, "broadcast_at": &now}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.CryptoUTXO{}).Where("reserved_for_withdrawal_id = ?", withdrawalID).
+			Updates(map[string]interface{}{"is_spent": true, "spent_tx_hash": txid, "spent_at": &now}).Error; err != nil {
+			return err
+		}
+		if s.publisher != nil {
+			withdrawal.TxHash = txid
+			withdrawal.Status = models.StatusBroadcasted
+			withdrawal.BroadcastAt = &now
+			key := withdrawalIdempotencyKey(withdrawalID, "broadcast")
+			if err := s.publisher.PublishWithdrawalBroadcast(ctx, tx, key, &withdrawal); err != nil {
+				s.logger.WithError(err).Warn("bitcoin: publish withdrawal-broadcast event failed")
+			}
+		}
+		return nil
+	})
+}
+
+// ConfirmWithdrawal marks a broadcast withdrawal as confirmed once enough
+// blocks have passed; confirmation counting itself is driven by the
+// BlockchainMonitor, which calls this once RequiredConfirms is met.
+func (s *Service) ConfirmWithdrawal(withdrawalID uint) error {
+	ctx := context.Background()
+	now := time.Now()
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var withdrawal models.CryptoWithdrawal
+		if err := tx.First(&withdrawal, withdrawalID).Error; err != nil {
+			return fmt.Errorf("bitcoin: load withdrawal %d: %w", withdrawalID, err)
+		}
+		if err := tx.Model(&models.CryptoWithdrawal{}).Where("id = ?", withdrawalID).
+			Updates(map[string]interface{}{"status": models.StatusConfirmed, "confirmed_at": &now}).Error; err != nil {
+			return err
+		}
+		if s.publisher != nil {
+			withdrawal.Status = models.StatusConfirmed
+			withdrawal.ConfirmedAt = &now
+			key := withdrawalIdempotencyKey(withdrawalID, "confirmed")
+			if err := s.publisher.PublishWithdrawalConfirmed(ctx, tx, key, &withdrawal); err != nil {
+				s.logger.WithError(err).Warn("bitcoin: publish withdrawal-confirmed event failed")
+			}
+		}
+		return nil
+	})
+}
+
+// CancelWithdrawal releases a withdrawal's reserved UTXOs and marks it
+// failed with the given reason. It refuses to cancel anything already
+// broadcast.
+func (s *Service) CancelWithdrawal(withdrawalID uint, reason string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var withdrawal models.CryptoWithdrawal
+		if err := tx.First(&withdrawal, withdrawalID).Error; err != nil {
+			return fmt.Errorf("bitcoin: load withdrawal %d: %w", withdrawalID, err)
+		}
+		if withdrawal.Status == models.StatusBroadcasted || withdrawal.Status == models.StatusConfirmed {
+			return fmt.Errorf("bitcoin: cannot cancel withdrawal %d in status %q", withdrawalID, withdrawal.Status)
+		}
+		if err := tx.Model(&models.CryptoUTXO{}).Where("reserved_for_withdrawal_id = ?", withdrawalID).
+			Update("reserved_for_withdrawal_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.CryptoWithdrawal{}).Where("id = ?", withdrawalID).
+			Updates(map[string]interface{}{"status": models.StatusFailed, "failure_reason": reason}).Error
+	})
+}
+
+// loadWithdrawalAndUTXOs fetches a withdrawal and the UTXOs reserved for it.
+func (s *Service) loadWithdrawalAndUTXOs(withdrawalID uint) (models.BitcoinWithdrawal, []models.CryptoUTXO, error) {
+	var withdrawal models.BitcoinWithdrawal
+	if err := s.db.Where("id = ?", withdrawalID).First(&withdrawal).Error; err != nil {
+		return models.BitcoinWithdrawal{}, nil, fmt.Errorf("bitcoin: load withdrawal %d: %w", withdrawalID, err)
+	}
+	var reserved []models.CryptoUTXO
+	if err := s.db.Where("reserved_for_withdrawal_id = ?", withdrawalID).Find(&reserved).Error; err != nil {
+		return models.BitcoinWithdrawal{}, nil, fmt.Errorf("bitcoin: load reserved utxos: %w", err)
+	}
+	return withdrawal, reserved, nil
+}
+
+// satoshisToBTCString formats an integer satoshi amount as the fixed
+// 8-decimal BTC string used throughout the crypto models (CryptoUTXO.Amount,
+// CryptoWithdrawal.Amount, etc.).
+func satoshisToBTCString(sats int64) string {
+	return fmt.Sprintf("%.8f", float64(sats)/1e8)
+}
+
+// btcStringToSatoshis parses one of those fixed-8-decimal BTC strings back
+// into satoshis. A malformed value (which should never happen for rows this
+// package itself wrote) is treated as zero rather than panicking.
+func btcStringToSatoshis(btc string) int64 {
+	amt, ok := new(big.Float).SetString(btc)
+	if !ok {
+		return 0
+	}
+	sats, _ := new(big.Float).Mul(amt, big.NewFloat(1e8)).Int64()
+	return sats
+}